@@ -0,0 +1,11 @@
+// Package assets bundles the static files needed to render TA charts,
+// so charts can be served without accessing network or the repo on disk.
+package assets
+
+import "embed"
+
+// FS holds echarts.min.js and the theme scripts, with paths relative to this directory,
+// e.g. "echarts.min.js" and "themes/vintage.js".
+//
+//go:embed echarts.min.js themes/*.js
+var FS embed.FS
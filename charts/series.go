@@ -156,6 +156,12 @@ type SingleSeries struct {
 	RenderLabelForZeroData  types.Bool `json:"renderLabelForZeroData,omitempty"`
 	SelectedMode            types.Bool `json:"selectedMode,omitempty"`
 
+	// Tooltip settings of the series, e.g. to leave the series out of axis tooltip
+	Tooltip *opts.Tooltip `json:"tooltip,omitempty"`
+
+	// Drawing order of the series, series with smaller z are drawn below
+	Z int `json:"z,omitempty"`
+
 	// series options
 	*opts.Encode        `json:"encode,omitempty"`
 	*opts.ItemStyle     `json:"itemStyle,omitempty"`
@@ -204,6 +210,20 @@ func WithSeriesAnimation(enable bool) SeriesOpts {
 	}
 }
 
+// WithSeriesTooltipOpts sets the tooltip of the series.
+func WithSeriesTooltipOpts(opt opts.Tooltip) SeriesOpts {
+	return func(s *SingleSeries) {
+		s.Tooltip = &opt
+	}
+}
+
+// WithSeriesZ sets the drawing order of the series, series with smaller z are drawn below.
+func WithSeriesZ(z int) SeriesOpts {
+	return func(s *SingleSeries) {
+		s.Z = z
+	}
+}
+
 func WithSeriesSymbolKeepAspect(enable bool) SeriesOpts {
 	return func(s *SingleSeries) {
 		s.SymbolKeepAspect = opts.Bool(enable)
@@ -493,8 +513,10 @@ func WithMarkLineStyleOpts(opt opts.MarkLineStyle) SeriesOpts {
 // WithMarkLineNameCoordItemOpts sets the coordinates of the MarkLine.
 func WithMarkLineNameCoordItemOpts(opt ...opts.MarkLineNameCoordItem) SeriesOpts {
 	type MLNameCoord struct {
-		Name  string        `json:"name,omitempty"`
-		Coord []interface{} `json:"coord"`
+		Name      string          `json:"name,omitempty"`
+		Label     *opts.Label     `json:"label,omitempty"`
+		LineStyle *opts.LineStyle `json:"lineStyle,omitempty"`
+		Coord     []interface{}   `json:"coord"`
 	}
 	return func(s *SingleSeries) {
 		if s.MarkLines == nil {
@@ -503,7 +525,10 @@ func WithMarkLineNameCoordItemOpts(opt ...opts.MarkLineNameCoordItem) SeriesOpts
 		for _, o := range opt {
 			s.MarkLines.Data = append(
 				s.MarkLines.Data,
-				[]MLNameCoord{{Name: o.Name, Coord: o.Coordinate0}, {Coord: o.Coordinate1}},
+				[]MLNameCoord{
+					{Name: o.Name, Label: o.Label, LineStyle: o.LineStyle, Coord: o.Coordinate0},
+					{Coord: o.Coordinate1},
+				},
 			)
 		}
 	}
@@ -255,6 +255,12 @@ type MarkLineNameYAxisItem struct {
 	// It may be the direct name of a dimension, like x,
 	// or angle for line charts, or open, or close for candlestick charts.
 	ValueDim string `json:"valueDim,omitempty"`
+
+	// Mark line text options.
+	Label *Label `json:"label,omitempty"`
+
+	// Line style of this mark line.
+	LineStyle *LineStyle `json:"lineStyle,omitempty"`
 }
 
 // MarkLineNameXAxisItem defines a MarkLine on a X axis.
@@ -288,6 +294,12 @@ type MarkLineNameCoordItem struct {
 	// It may be the direct name of a dimension, like x,
 	// or angle for line charts, or open, or close for candlestick charts.
 	ValueDim string `json:"valueDim,omitempty"`
+
+	// Mark line text options.
+	Label *Label `json:"label,omitempty"`
+
+	// Line style of this mark line.
+	LineStyle *LineStyle `json:"lineStyle,omitempty"`
 }
 
 // MarkAreas represents a series of markareas.
@@ -464,6 +476,9 @@ type MarkPointNameCoordItem struct {
 
 	// Symbol rotate.
 	SymbolRotate float32 `json:"symbolRotate,omitempty"`
+
+	// Offset of symbol relative to the coordinate, e.g. []interface{}{0, "50%"} to place the top of symbol on it.
+	SymbolOffset []interface{} `json:"symbolOffset,omitempty"`
 }
 
 // RippleEffect is the option set for the ripple effect.
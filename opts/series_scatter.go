@@ -59,4 +59,7 @@ type ScatterData struct {
 
 	// Index of y axis to combine with, which is useful for multiple y axes in one chart.
 	YAxisIndex int `json:"yAxisIndex,omitempty"`
+
+	// ItemStyle settings in this series data.
+	ItemStyle *ItemStyle `json:"itemStyle,omitempty"`
 }
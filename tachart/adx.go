@@ -0,0 +1,148 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iamjinlei/go-tart"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type adx struct {
+	nm   string
+	n    int64
+	opts indicatorOptions
+	ci   int
+}
+
+// NewADX creates average directional index indicator with +DI and -DI lines, e.g. NewADX(14, WithThreshold(25)).
+func NewADX(n int, options ...IndicatorOption) Indicator {
+	return &adx{
+		nm:   fmt.Sprintf("ADX(%v)", n),
+		n:    int64(n),
+		opts: newIndicatorOptions(options),
+	}
+}
+
+func (a adx) Name() string {
+	return a.nm
+}
+
+func (a adx) YAxisLabel() string {
+	return YLabelFormatterFunc(0)
+}
+
+func (a adx) YAxisMin() string {
+	return FixedValueFunc(0)
+}
+
+func (a adx) YAxisMax() string {
+	return FixedValueFunc(100)
+}
+
+func (a adx) GetNumColors() int {
+	return 3
+}
+
+func (a *adx) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	a.ci = colorIndex
+	return []opts.Title{
+		LegendTitle(a.nm, top, left, a.ci),
+		LegendTitle(fmt.Sprintf("+DI(%v)", a.n), top+chartLabelFontHeight, left, a.ci+1),
+		LegendTitle(fmt.Sprintf("-DI(%v)", a.n), top+2*chartLabelFontHeight, left, a.ci+2),
+	}
+}
+
+func (a adx) GenChart(_, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	vals := tart.AdxArr(highs, lows, closes, a.n)
+	// the first ADX is the average of n DX values, which are available after n periods
+	gapWarmUp(2*int(a.n)-1, vals)
+	plusDi, minusDi := directionalIndicators(highs, lows, closes, int(a.n))
+
+	line := func(nm string, vals []float64, ci int, seriesOpts ...charts.SeriesOpts) *charts.Line {
+		seriesOpts = append([]charts.SeriesOpts{
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color:   Color(ci),
+				Opacity: opacityMed,
+			}),
+		}, seriesOpts...)
+		return charts.NewLine().
+			SetXAxis(xAxis).
+			AddSeries(nm, lineData(vals), seriesOpts...)
+	}
+
+	adxOpts := []charts.SeriesOpts{}
+	if a.opts.threshold != nil {
+		adxOpts = append(adxOpts,
+			charts.WithMarkLineNameYAxisItemOpts(
+				opts.MarkLineNameYAxisItem{
+					Name:  "threshold",
+					YAxis: *a.opts.threshold,
+				},
+			),
+			charts.WithMarkLineStyleOpts(
+				opts.MarkLineStyle{
+					Symbol: []string{"none", "none"},
+					LineStyle: &opts.LineStyle{
+						Color:   colorDownBar,
+						Opacity: opacityMed,
+					},
+				},
+			),
+		)
+	}
+
+	l := line(a.nm, vals, a.ci, adxOpts...)
+	l.Overlap(
+		line(fmt.Sprintf("+DI(%v)", a.n), plusDi, a.ci+1),
+		line(fmt.Sprintf("-DI(%v)", a.n), minusDi, a.ci+2),
+	)
+	return l
+}
+
+// directionalIndicators computes +DI and -DI with Wilder's smoothing, the same way go-tart computes DX.
+// Values are NaN until n periods of directional movement are available.
+func directionalIndicators(highs, lows, closes []float64, n int) ([]float64, []float64) {
+	plusDi := make([]float64, len(closes))
+	minusDi := make([]float64, len(closes))
+	k := float64(n-1) / float64(n)
+	var sTr, sPlusDm, sMinusDm float64
+	for i := range closes {
+		plusDi[i], minusDi[i] = math.NaN(), math.NaN()
+		if i == 0 {
+			continue
+		}
+
+		tr := math.Max(highs[i], closes[i-1]) - math.Min(lows[i], closes[i-1])
+		plusDm := highs[i] - highs[i-1]
+		minusDm := lows[i-1] - lows[i]
+		if minusDm > plusDm || plusDm < 0 {
+			plusDm = 0
+		}
+		if plusDm > minusDm || minusDm < 0 {
+			minusDm = 0
+		}
+
+		if i < n {
+			sTr += tr
+			sPlusDm += plusDm
+			sMinusDm += minusDm
+			continue
+		}
+		sTr = sTr*k + tr
+		sPlusDm = sPlusDm*k + plusDm
+		sMinusDm = sMinusDm*k + minusDm
+		if sTr != 0 {
+			plusDi[i] = sPlusDm / sTr * 100
+			minusDi[i] = sMinusDm / sTr * 100
+		}
+	}
+	return plusDi, minusDi
+}
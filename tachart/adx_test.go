@@ -0,0 +1,47 @@
+package tachart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iamjinlei/go-tart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectionalIndicators(t *testing.T) {
+	highs, lows, closes := []float64{}, []float64{}, []float64{}
+	for _, cdl := range testCdls {
+		highs = append(highs, cdl.H)
+		lows = append(lows, cdl.L)
+		closes = append(closes, cdl.C)
+	}
+
+	n := 5
+	plusDi, minusDi := directionalIndicators(highs, lows, closes, n)
+	dx := tart.DxArr(highs, lows, closes, int64(n))
+	for i := range closes {
+		if i < n {
+			assert.True(t, math.IsNaN(plusDi[i]))
+			assert.True(t, math.IsNaN(minusDi[i]))
+			continue
+		}
+		// DX is derived from +DI and -DI
+		assert.InDelta(t, dx[i], math.Abs(plusDi[i]-minusDi[i])/(plusDi[i]+minusDi[i])*100, 1e-9)
+	}
+}
+
+func TestADX(t *testing.T) {
+	c := New(*NewConfig().AddIndicator(NewADX(5, WithThreshold(25))))
+	chart, err := c.genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	vals := seriesValues(t, chart.MultiSeries, "ADX(5)")
+	assert.Equal(t, "-", vals[8])
+	assert.Greater(t, vals[9], 0.0)
+	assert.Len(t, seriesValues(t, chart.MultiSeries, "+DI(5)"), len(testCdls))
+	for _, s := range chart.MultiSeries {
+		if s.Name == "ADX(5)" {
+			assert.NotNil(t, s.MarkLines)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package tachart
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/iamjinlei/go-tart"
 
@@ -24,27 +23,27 @@ func NewATR(n int) Indicator {
 	}
 }
 
-func (a atr) name() string {
+func (a atr) Name() string {
 	return a.nm
 }
 
-func (a atr) yAxisLabel() string {
-	return strings.Replace(yLabelFormatterFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", a.dp), -1)
+func (a atr) YAxisLabel() string {
+	return YLabelFormatterFunc(a.dp)
 }
 
-func (a atr) yAxisMin() string {
-	return strings.Replace(minRoundFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", a.dp), -1)
+func (a atr) YAxisMin() string {
+	return MinRoundFunc(a.dp)
 }
 
-func (a atr) yAxisMax() string {
-	return strings.Replace(maxRoundFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", a.dp), -1)
+func (a atr) YAxisMax() string {
+	return MaxRoundFunc(a.dp)
 }
 
-func (a atr) getNumColors() int {
+func (a atr) GetNumColors() int {
 	return 1
 }
 
-func (a *atr) getTitleOpts(top, left int, colorIndex int) []opts.Title {
+func (a *atr) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
 	a.ci = colorIndex
 	return []opts.Title{
 		{
@@ -59,21 +58,15 @@ func (a *atr) getTitleOpts(top, left int, colorIndex int) []opts.Title {
 	}
 }
 
-func (a atr) genChart(_, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+func (a atr) GenChart(_, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
 	vals := tart.AtrArr(highs, lows, closes, a.n)
-	for i := 0; i < int(a.n); i++ {
-		vals[i] = vals[a.n]
-	}
+	// true range is available from the 2nd period
+	gapWarmUp(int(a.n), vals)
 	a.dp = decimals(vals)
 
-	items := []opts.LineData{}
-	for _, v := range vals {
-		items = append(items, opts.LineData{Value: v})
-	}
-
 	return charts.NewLine().
 		SetXAxis(xAxis).
-		AddSeries(a.nm, items,
+		AddSeries(a.nm, lineData(vals),
 			charts.WithLineChartOpts(opts.LineChart{
 				Symbol:     "none",
 				XAxisIndex: gridIndex,
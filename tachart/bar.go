@@ -1,9 +1,6 @@
 package tachart
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/otetz/go-tachart/charts"
 	"github.com/otetz/go-tachart/opts"
 )
@@ -23,27 +20,27 @@ func NewBar(name string, vals []float64) Indicator {
 	}
 }
 
-func (b bar) name() string {
+func (b bar) Name() string {
 	return b.nm
 }
 
-func (b bar) yAxisLabel() string {
-	return strings.Replace(yLabelFormatterFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", b.dp), -1)
+func (b bar) YAxisLabel() string {
+	return YLabelFormatterFunc(b.dp)
 }
 
-func (b bar) yAxisMin() string {
-	return strings.Replace(minRoundFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", b.dp), -1)
+func (b bar) YAxisMin() string {
+	return MinRoundFunc(b.dp)
 }
 
-func (b bar) yAxisMax() string {
-	return strings.Replace(maxRoundFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", b.dp), -1)
+func (b bar) YAxisMax() string {
+	return MaxRoundFunc(b.dp)
 }
 
-func (b bar) getNumColors() int {
+func (b bar) GetNumColors() int {
 	return 1
 }
 
-func (b *bar) getTitleOpts(top, left int, colorIndex int) []opts.Title {
+func (b *bar) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
 	b.ci = colorIndex
 	return []opts.Title{
 		{
@@ -58,7 +55,7 @@ func (b *bar) getTitleOpts(top, left int, colorIndex int) []opts.Title {
 	}
 }
 
-func (b bar) genChart(_, _, _, _, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+func (b bar) GenChart(_, _, _, _, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
 	barItems := []opts.BarData{}
 	for _, v := range b.vals {
 		style := &opts.ItemStyle{
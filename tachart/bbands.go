@@ -14,81 +14,64 @@ type bbands struct {
 	n       int64
 	nStdDev float64
 	isSma   bool
+	opts    indicatorOptions
 	ci      int
 }
 
-func NewBBandsSMA(n int, nStdDev float64) Indicator {
+func NewBBandsSMA(n int, nStdDev float64, options ...IndicatorOption) Indicator {
 	return &bbands{
 		nm:      fmt.Sprintf("BBANDS(SMA, %v)", n),
 		n:       int64(n),
 		nStdDev: nStdDev,
 		isSma:   true,
+		opts:    newIndicatorOptions(options),
 	}
 }
 
-func NewBBandsEMA(n int, nStdDev float64) Indicator {
+func NewBBandsEMA(n int, nStdDev float64, options ...IndicatorOption) Indicator {
 	return &bbands{
 		nm:      fmt.Sprintf("BBANDS(EMA, %v)", n),
 		n:       int64(n),
 		nStdDev: nStdDev,
 		isSma:   false,
+		opts:    newIndicatorOptions(options),
 	}
 }
 
-func (b bbands) name() string {
+func (b bbands) Name() string {
 	return b.nm
 }
 
-func (b bbands) yAxisLabel() string {
+func (b bbands) YAxisLabel() string {
 	return ""
 }
 
-func (b bbands) yAxisMin() string {
+func (b bbands) YAxisMin() string {
 	return ""
 }
 
-func (b bbands) yAxisMax() string {
+func (b bbands) YAxisMax() string {
 	return ""
 }
 
-func (b bbands) getNumColors() int {
+func (b bbands) GetNumColors() int {
 	return 2
 }
 
-func (b *bbands) getTitleOpts(top, left int, colorIndex int) []opts.Title {
-	b.ci = colorIndex
-	return []opts.Title{
-		{
-			TitleStyle: &opts.TextStyle{
-				Color:    colors[b.ci],
-				FontSize: chartLabelFontSize,
-			},
-			Title: b.nm + "-Ma",
-			Left:  px(left),
-			Top:   px(top),
-		},
-		{
-			TitleStyle: &opts.TextStyle{
-				Color:    colors[b.ci+1],
-				FontSize: chartLabelFontSize,
-			},
-			Title: b.nm + "-Upper",
-			Left:  px(left),
-			Top:   px(top + chartLabelFontHeight),
-		},
-		{
-			TitleStyle: &opts.TextStyle{
-				Color:    colors[b.ci+1],
-				FontSize: chartLabelFontSize,
-			},
-			Title: b.nm + "-Lower",
-			Left:  px(left),
-			Top:   px(top + 2*chartLabelFontHeight),
-		},
+// Lookback is the SMA period, EMA bands depend on all prior candles.
+func (b bbands) Lookback() int {
+	if b.isSma {
+		return int(b.n)
 	}
+	return 0
+}
+
+func (b *bbands) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	b.ci = colorIndex
+	return channelTitles(b.nm, "Ma", top, left, b.ci, b.opts)
 }
 
-func (b bbands) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+func (b bbands) GenChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
 	var u, m, l []float64
 	if b.isSma {
 		u, m, l = tart.BBandsArr(tart.SMA, closes, b.n, b.nStdDev, b.nStdDev)
@@ -96,52 +79,7 @@ func (b bbands) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIn
 		u, m, l = tart.BBandsArr(tart.EMA, closes, b.n, b.nStdDev, b.nStdDev)
 	}
 
-	uItems := []opts.LineData{}
-	mItems := []opts.LineData{}
-	lItems := []opts.LineData{}
-	for i := 0; i < len(m); i++ {
-		uItems = append(uItems, opts.LineData{Value: u[i]})
-		mItems = append(mItems, opts.LineData{Value: m[i]})
-		lItems = append(lItems, opts.LineData{Value: l[i]})
-	}
+	gapWarmUp(int(b.n)-1, u, m, l)
 
-	ml := charts.NewLine().
-		SetXAxis(xAxis).
-		AddSeries(b.nm+"-Ma", mItems,
-			charts.WithLineChartOpts(opts.LineChart{
-				Symbol:     "none",
-				XAxisIndex: gridIndex,
-				YAxisIndex: gridIndex,
-			}),
-			charts.WithLineStyleOpts(opts.LineStyle{
-				Color:   colors[b.ci],
-				Opacity: opacityMed,
-			}))
-	ul := charts.NewLine().
-		SetXAxis(xAxis).
-		AddSeries(b.nm+"-Upper", uItems,
-			charts.WithLineChartOpts(opts.LineChart{
-				Symbol:     "none",
-				XAxisIndex: gridIndex,
-				YAxisIndex: gridIndex,
-			}),
-			charts.WithLineStyleOpts(opts.LineStyle{
-				Color:   colors[b.ci+1],
-				Opacity: opacityMed,
-			}))
-	ll := charts.NewLine().
-		SetXAxis(xAxis).
-		AddSeries(b.nm+"-Lower", lItems,
-			charts.WithLineChartOpts(opts.LineChart{
-				Symbol:     "none",
-				XAxisIndex: gridIndex,
-				YAxisIndex: gridIndex,
-			}),
-			charts.WithLineStyleOpts(opts.LineStyle{
-				Color:   colors[b.ci+1],
-				Opacity: opacityMed,
-			}))
-
-	ml.Overlap(ul, ll)
-	return ml
+	return genChannel(b.nm, "Ma", u, m, l, b.ci, b.opts, xAxis, gridIndex)
 }
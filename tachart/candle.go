@@ -1,10 +1,227 @@
 package tachart
 
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/otetz/go-tachart/charts"
+)
+
+// TimeGap controls how time gaps between candles (e.g. weekends, overnight, holidays) are drawn.
+// It only applies to candles with time (Candle.T) set.
+type TimeGap byte
+
+const (
+	// candles are placed next to each other regardless of time gaps
+	GapCompress TimeGap = iota
+	// empty slots are inserted on x-axis for missing candles
+	GapShow
+)
+
 type Candle struct {
-	Label string  // x-axis label for this candle. Usually a timestamp, e.g. "2018/1/24 08:00"
-	O     float64 // open
-	H     float64 // high
-	L     float64 // low
-	C     float64 // close
-	V     float64 // volume
+	Label string    // x-axis label for this candle. Usually a timestamp, e.g. "2018/1/24 08:00". Formatted from T if empty
+	T     time.Time // candle time, optional. If set, candles are keyed by T instead of Label and must be sorted by T
+	O     float64   // open
+	H     float64   // high
+	L     float64   // low
+	C     float64   // close
+	V     float64   // volume
+}
+
+// sameCandle tells if two candles are of the same period.
+func sameCandle(a, b Candle) bool {
+	if !a.T.IsZero() || !b.T.IsZero() {
+		return a.T.Equal(b.T)
+	}
+	return a.Label == b.Label
+}
+
+// heikinAshi returns Heikin-Ashi candles of cdls.
+func heikinAshi(cdls []Candle) []Candle {
+	ha := make([]Candle, len(cdls))
+	for i, cdl := range cdls {
+		h := cdl
+		h.C = (cdl.O + cdl.H + cdl.L + cdl.C) / 4
+		if i == 0 {
+			h.O = (cdl.O + cdl.C) / 2
+		} else {
+			h.O = (ha[i-1].O + ha[i-1].C) / 2
+		}
+		h.H = math.Max(cdl.H, math.Max(h.O, h.C))
+		h.L = math.Min(cdl.L, math.Min(h.O, h.C))
+		ha[i] = h
+	}
+	return ha
+}
+
+// candleAxis maps candles to x-axis slots. There is one slot per candle,
+// unless time gaps are shown, in which case empty slots are inserted for missing candles.
+type candleAxis struct {
+	labels   []string       // x-axis label of each slot
+	slots    []int          // slot of each candle
+	times    []time.Time    // time of each candle, nil if candles are keyed by label
+	interval time.Duration  // candle interval, 0 if candles are keyed by label
+	labelIdx map[string]int // label => slot of candles
+	future   int            // # of empty slots after the last candle
+	format   func(time.Time) string
+}
+
+func newCandleAxis(cfg Config, cdls []Candle) (*candleAxis, error) {
+	a := &candleAxis{
+		labelIdx: map[string]int{},
+	}
+	if len(cdls) == 0 || cdls[0].T.IsZero() {
+		for i, cdl := range cdls {
+			if _, found := a.labelIdx[cdl.Label]; found {
+				return nil, ErrDuplicateCandleLabel
+			}
+			a.labels = append(a.labels, cdl.Label)
+			a.slots = append(a.slots, i)
+			a.labelIdx[cdl.Label] = i
+		}
+		return a, nil
+	}
+
+	for i, cdl := range cdls {
+		if i > 0 {
+			if cdl.T.Equal(cdls[i-1].T) {
+				return nil, ErrDuplicateCandleTime
+			}
+			if cdl.T.Before(cdls[i-1].T) {
+				return nil, ErrUnsortedCandles
+			}
+			if d := cdl.T.Sub(cdls[i-1].T); a.interval == 0 || d < a.interval {
+				a.interval = d
+			}
+		}
+		a.times = append(a.times, cdl.T)
+	}
+	if cfg.candleInterval > 0 {
+		a.interval = cfg.candleInterval
+	}
+
+	layout := cfg.timeFormat
+	if layout == "" {
+		layout = defaultTimeFormat(cfg, a.times)
+	}
+	a.format = func(t time.Time) string {
+		if cfg.timeLocation != nil {
+			t = t.In(cfg.timeLocation)
+		}
+		return t.Format(layout)
+	}
+
+	for i, cdl := range cdls {
+		slot := i
+		if cfg.timeGap == GapShow && i > 0 && a.interval > 0 {
+			slot = a.slots[i-1] + int((cdl.T.Sub(cdls[i-1].T)+a.interval/2)/a.interval)
+			if slot <= a.slots[i-1] {
+				slot = a.slots[i-1] + 1
+			}
+			// empty slots in between
+			for k := a.slots[i-1] + 1; k < slot; k++ {
+				a.labels = append(a.labels, a.format(cdls[i-1].T.Add(time.Duration(k-a.slots[i-1])*a.interval)))
+			}
+		}
+
+		label := cdl.Label
+		if label == "" {
+			label = a.format(cdl.T)
+		}
+		a.labels = append(a.labels, label)
+		a.slots = append(a.slots, slot)
+		a.labelIdx[label] = slot
+	}
+
+	return a, nil
+}
+
+func defaultTimeFormat(cfg Config, times []time.Time) string {
+	for _, t := range times {
+		if cfg.timeLocation != nil {
+			t = t.In(cfg.timeLocation)
+		}
+		if t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 {
+			return "2006/01/02 15:04"
+		}
+	}
+	return "2006/01/02"
+}
+
+// extend appends n empty slots after the last candle. Slots of candles keyed by time are
+// labeled with the time to come, otherwise with the offset from the last candle, e.g. "+1".
+func (a *candleAxis) extend(n int) {
+	if len(a.slots) == 0 {
+		return
+	}
+	for k := 1; k <= n; k++ {
+		if a.times != nil && a.interval > 0 {
+			a.labels = append(a.labels, a.format(a.times[len(a.times)-1].Add(time.Duration(k)*a.interval)))
+		} else {
+			a.labels = append(a.labels, fmt.Sprintf("+%v", k))
+		}
+	}
+	a.future += n
+}
+
+func (a candleAxis) hasGaps() bool {
+	return len(a.labels) != len(a.slots)+a.future
+}
+
+// eventSlot returns the slot of the candle an event belongs to.
+// Events with time belong to the candle whose period contains the event time.
+func (a candleAxis) eventSlot(e Event) (int, error) {
+	if e.T.IsZero() || a.times == nil {
+		slot, found := a.labelIdx[e.Label]
+		if !found {
+			return 0, ErrUnknownEventCandle
+		}
+		return slot, nil
+	}
+
+	// first candle after event time
+	i := sort.Search(len(a.times), func(i int) bool {
+		return a.times[i].After(e.T)
+	})
+	if i == 0 {
+		return 0, ErrUnknownEventCandle
+	}
+	if i == len(a.times) && !e.T.Equal(a.times[i-1]) && !e.T.Before(a.times[i-1].Add(a.interval)) {
+		return 0, ErrUnknownEventCandle
+	}
+	return a.slots[i-1], nil
+}
+
+// expandSeries moves series data of candles to their slots, leaving empty slots blank.
+// Data beyond the last candle goes to the empty slots after it.
+func (a candleAxis) expandSeries(series charts.MultiSeries) {
+	if !a.hasGaps() {
+		return
+	}
+	for i := range series {
+		v := reflect.ValueOf(series[i].Data)
+		if v.Kind() != reflect.Slice || v.Len() < len(a.slots) || v.Len() > len(a.slots)+a.future {
+			continue
+		}
+		expanded := reflect.MakeSlice(v.Type(), len(a.labels), len(a.labels))
+		if v.Type().Elem().Kind() == reflect.Struct {
+			if _, found := v.Type().Elem().FieldByName("Value"); found {
+				for k := 0; k < expanded.Len(); k++ {
+					expanded.Index(k).FieldByName("Value").Set(reflect.ValueOf("-"))
+				}
+			}
+		}
+		last := a.slots[len(a.slots)-1]
+		for k := 0; k < v.Len(); k++ {
+			slot := last + k - len(a.slots) + 1
+			if k < len(a.slots) {
+				slot = a.slots[k]
+			}
+			expanded.Index(slot).Set(v.Index(k))
+		}
+		series[i].Data = expanded.Interface()
+	}
 }
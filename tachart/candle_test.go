@@ -0,0 +1,96 @@
+package tachart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/otetz/go-tachart/opts"
+)
+
+func hourlyCandles(times ...string) []Candle {
+	cdls := []Candle{}
+	for _, s := range times {
+		t, _ := time.Parse("2006-01-02 15:04", s)
+		cdls = append(cdls, Candle{T: t, O: 1, H: 2, L: 0.5, C: 1.5, V: 100})
+	}
+	return cdls
+}
+
+func TestCandleAxisCompress(t *testing.T) {
+	cdls := hourlyCandles("2021-06-04 14:00", "2021-06-04 15:00", "2021-06-07 09:00")
+	axis, err := newCandleAxis(*NewConfig(), cdls)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2021/06/04 14:00", "2021/06/04 15:00", "2021/06/07 09:00"}, axis.labels)
+	assert.Equal(t, []int{0, 1, 2}, axis.slots)
+	assert.Equal(t, time.Hour, axis.interval)
+	assert.False(t, axis.hasGaps())
+
+	// event within the period of a candle
+	slot, err := axis.eventSlot(Event{T: cdls[1].T.Add(10 * time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, slot)
+	slot, err = axis.eventSlot(Event{T: cdls[2].T.Add(59 * time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, slot)
+	_, err = axis.eventSlot(Event{T: cdls[2].T.Add(time.Hour)})
+	assert.Equal(t, ErrUnknownEventCandle, err)
+	_, err = axis.eventSlot(Event{T: cdls[0].T.Add(-time.Minute)})
+	assert.Equal(t, ErrUnknownEventCandle, err)
+	slot, err = axis.eventSlot(Event{Label: "2021/06/07 09:00"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, slot)
+}
+
+func TestCandleAxisShowGaps(t *testing.T) {
+	cdls := hourlyCandles("2021-06-04 14:00", "2021-06-04 15:00", "2021-06-04 18:00")
+	loc := time.FixedZone("UTC+8", 8*3600)
+	cfg := NewConfig().SetTimeGap(GapShow).SetTimeFormat("15:04").SetTimeLocation(loc)
+	axis, err := newCandleAxis(*cfg, cdls)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"22:00", "23:00", "00:00", "01:00", "02:00"}, axis.labels)
+	assert.Equal(t, []int{0, 1, 4}, axis.slots)
+	assert.True(t, axis.hasGaps())
+
+	c := New(*cfg.AddOverlay(NewSMA(2)))
+	chart, err := c.genChart(cdls, []Event{{Type: Long, T: cdls[2].T}})
+	assert.NoError(t, err)
+	for _, s := range chart.MultiSeries {
+		if s.Name == "events" {
+			continue
+		}
+		assert.Equal(t, 5, sliceLen(s.Data), s.Name)
+	}
+}
+
+func TestCandleAxisErrors(t *testing.T) {
+	_, err := newCandleAxis(*NewConfig(), hourlyCandles("2021-06-04 14:00", "2021-06-04 14:00"))
+	assert.Equal(t, ErrDuplicateCandleTime, err)
+	_, err = newCandleAxis(*NewConfig(), hourlyCandles("2021-06-04 14:00", "2021-06-04 13:00"))
+	assert.Equal(t, ErrUnsortedCandles, err)
+	_, err = New(*NewConfig()).genChart(testCdls, []Event{{Type: Long, Label: "2018-1-24"}})
+	assert.Equal(t, ErrUnknownEventCandle, err)
+}
+
+func TestHeikinAshi(t *testing.T) {
+	cdls := []Candle{
+		{Label: "1", O: 10, H: 14, L: 8, C: 12},
+		{Label: "2", O: 12, H: 13, L: 9, C: 10},
+	}
+	ha := heikinAshi(cdls)
+	assert.Equal(t, Candle{Label: "1", O: 11, H: 14, L: 8, C: 11}, ha[0])
+	assert.Equal(t, Candle{Label: "2", O: 11, H: 13, L: 9, C: 11}, ha[1])
+
+	cfg := NewConfig().SetHeikinAshi(true).AddOverlay(NewSMA(1))
+	chart, err := New(*cfg).genChart(cdls, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{11, 11, 8, 14}, chart.MultiSeries[0].Data.([]opts.KlineData)[0].Value)
+	// overlays are computed on real prices by default
+	assert.Equal(t, []interface{}{12.0, 10.0}, seriesValues(t, chart.MultiSeries, "SMA(1)"))
+	assert.Contains(t, string(chart.Tooltip.Formatter), `{"0":[10,12,8,14],"1":[12,10,9,13]}`)
+
+	chart, err = New(*cfg.SetHeikinAshiIndicators(true)).genChart(cdls, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{11.0, 11.0}, seriesValues(t, chart.MultiSeries, "SMA(1)"))
+}
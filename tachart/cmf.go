@@ -0,0 +1,112 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type cmf struct {
+	nm string
+	n  int
+	ci int
+}
+
+// NewCMF creates Chaikin money flow indicator, e.g. NewCMF(20).
+func NewCMF(n int) Indicator {
+	return &cmf{
+		nm: fmt.Sprintf("CMF(%v)", n),
+		n:  n,
+	}
+}
+
+func (c cmf) Name() string {
+	return c.nm
+}
+
+func (c cmf) YAxisLabel() string {
+	return YLabelFormatterFunc(2)
+}
+
+func (c cmf) YAxisMin() string {
+	return MinPadFunc()
+}
+
+func (c cmf) YAxisMax() string {
+	return MaxPadFunc()
+}
+
+func (c cmf) GetNumColors() int {
+	return 1
+}
+
+func (c cmf) Lookback() int {
+	return c.n
+}
+
+func (c *cmf) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	c.ci = colorIndex
+	return []opts.Title{
+		LegendTitle(c.nm, top, left, c.ci),
+	}
+}
+
+func (c cmf) GenChart(_, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	// money flow volume
+	mfv := make([]float64, len(closes))
+	for i := range closes {
+		if r := highs[i] - lows[i]; r != 0 {
+			mfv[i] = ((closes[i] - lows[i]) - (highs[i] - closes[i])) / r * vols[i]
+		}
+	}
+
+	vals := make([]float64, len(closes))
+	var sumMfv, sumV float64
+	for i := range closes {
+		sumMfv += mfv[i]
+		sumV += vols[i]
+		if i >= c.n {
+			sumMfv -= mfv[i-c.n]
+			sumV -= vols[i-c.n]
+		}
+		switch {
+		case i < c.n-1:
+			vals[i] = math.NaN()
+		case sumV == 0:
+			vals[i] = 0
+		default:
+			vals[i] = sumMfv / sumV
+		}
+	}
+
+	return charts.NewLine().
+		SetXAxis(xAxis).
+		AddSeries(c.nm, lineData(vals),
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color:   Color(c.ci),
+				Opacity: opacityMed,
+			}),
+			charts.WithMarkLineNameYAxisItemOpts(
+				opts.MarkLineNameYAxisItem{
+					Name:  "zero",
+					YAxis: 0,
+				},
+			),
+			charts.WithMarkLineStyleOpts(
+				opts.MarkLineStyle{
+					Symbol: []string{"none", "none"},
+					LineStyle: &opts.LineStyle{
+						Color:   colorDownBar,
+						Opacity: opacityMed,
+					},
+				},
+			),
+		)
+}
@@ -6,6 +6,9 @@ const (
 	opacityHeavy = 0.7
 	opacityMed   = 0.5
 	opacityLight = 0.3
+	opacityFill  = 0.15
+
+	colorPriceLine = "#3A6EA5"
 )
 
 var (
@@ -4,6 +4,7 @@ import (
 	"html/template"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 // page is conceptually divided into 3x3 grids:
@@ -39,6 +40,32 @@ type Config struct {
 	draggable          bool
 	eventDescWrapWidth int // wrap width of event desc on tooltip, 0 means no-wrap
 	jsFuncs            []string
+	liveUpdateURL      string         // SSE stream url the chart page subscribes to, empty means static chart
+	skipUnmatched      bool           // skip trades, range events and anchors matching no candle, e.g. dropped off a Stream
+	timeFormat         string         // layout of x-axis labels formatted from candle time, auto-detected if empty
+	timeLocation       *time.Location // time zone of x-axis labels, use the location of candle time if nil
+	timeGap            TimeGap
+	candleInterval     time.Duration // interval between candles, auto-detected if 0
+	warmUp             WarmUp
+	priceStyle         PriceStyle
+	profileRows        int // # of price buckets of volume profile, 0 means no volume profile
+	profileRange       ProfileRange
+	valueArea          float64 // portion of volume in the value area of volume profile
+	leftMargin         int
+	rightMargin        int
+	paneGap            int // vertical gap between charts
+	sliderHeight       int
+	hideSlider         bool
+	hideVolume         bool
+	priceWeight        float64   // height weight of the candlestick chart
+	volumeWeight       float64   // height weight of the volume chart
+	indicatorWeights   []float64 // height weights of indicator charts, in the order of indicators
+	trades             []Trade
+	rangeEvents        []RangeEvent
+	levels             []level
+	pivots             []pivots
+	heikinAshi         bool
+	haIndicators       bool // compute overlays and indicators on Heikin-Ashi prices instead of real prices
 }
 
 func NewConfig() *Config {
@@ -48,12 +75,19 @@ func NewConfig() *Config {
 		indicators: []Indicator{},
 		assetsHost: "https://go-echarts.github.io/go-echarts-assets/assets/",
 		theme:      ThemeWhite,
+		valueArea:  defaultValueArea,
 		layout: pageLayout{
 			chartWidth:  900,
 			chartHeight: 500,
 		},
 		draggable:          false,
 		eventDescWrapWidth: 160,
+		leftMargin:         defaultLeft,
+		rightMargin:        defaultRight,
+		paneGap:            defaultGap,
+		sliderHeight:       defaultSliderH,
+		priceWeight:        2,
+		volumeWeight:       1,
 	}
 }
 
@@ -122,7 +156,84 @@ func (c *Config) AddOverlay(vals ...Indicator) *Config {
 }
 
 func (c *Config) AddIndicator(vals ...Indicator) *Config {
+	return c.AddIndicatorWithWeight(1, vals...)
+}
+
+func (c *Config) AddIndicatorWithWeight(weight float64, vals ...Indicator) *Config {
+	// indicator charts take height in proportion to weights, which are 1 for AddIndicator,
+	// 2 for the candlestick chart and 1 for the volume chart by default.
+	// Weights not > 0 fall back to 1
+	if !(weight > 0) {
+		weight = 1
+	}
 	c.indicators = append(c.indicators, vals...)
+	for range vals {
+		c.indicatorWeights = append(c.indicatorWeights, weight)
+	}
+	return c
+}
+
+func (c *Config) SetPriceWeight(weight float64) *Config {
+	// weights not > 0 are ignored
+	if weight > 0 {
+		c.priceWeight = weight
+	}
+	return c
+}
+
+func (c *Config) SetVolumeWeight(weight float64) *Config {
+	// weights not > 0 are ignored
+	if weight > 0 {
+		c.volumeWeight = weight
+	}
+	return c
+}
+
+func (c *Config) SetShowVolume(show bool) *Config {
+	c.hideVolume = !show
+	return c
+}
+
+func (c *Config) SetShowSlider(show bool) *Config {
+	// without the slider, the chart is zoomed and dragged with mouse wheel and mouse drag
+	c.hideSlider = !show
+	return c
+}
+
+func (c *Config) SetSliderHeight(h int) *Config {
+	c.sliderHeight = h
+	return c
+}
+
+func (c *Config) SetMargins(left, right int) *Config {
+	c.leftMargin = left
+	c.rightMargin = right
+	return c
+}
+
+func (c *Config) SetPaneGap(gap int) *Config {
+	c.paneGap = gap
+	return c
+}
+
+func (c *Config) AddTrade(trades ...Trade) *Config {
+	c.trades = append(c.trades, trades...)
+	return c
+}
+
+func (c *Config) AddRangeEvent(events ...RangeEvent) *Config {
+	c.rangeEvents = append(c.rangeEvents, events...)
+	return c
+}
+
+func (c *Config) AddLevel(price float64, label string, style LevelStyle) *Config {
+	c.levels = append(c.levels, level{price: price, label: label, style: style})
+	return c
+}
+
+func (c *Config) AddPivots(method PivotMethod, period SessionBoundary) *Config {
+	// levels of each period are computed from the prior period, e.g. DailySession, WeeklySession or MonthlySession
+	c.pivots = append(c.pivots, pivots{method: method, boundary: period})
 	return c
 }
 
@@ -140,3 +251,69 @@ func (c *Config) SetAssetsHost(assetsHost string) *Config {
 	c.assetsHost = assetsHost
 	return c
 }
+
+func (c *Config) SetLiveUpdateURL(url string) *Config {
+	// chart page subscribes to the SSE stream at url (e.g. served by Stream)
+	// and applies pushed options in place
+	c.liveUpdateURL = url
+	return c
+}
+
+func (c *Config) SetTimeFormat(layout string) *Config {
+	// layout (see time.Time.Format) of x-axis labels formatted from candle time
+	c.timeFormat = layout
+	return c
+}
+
+func (c *Config) SetTimeLocation(loc *time.Location) *Config {
+	c.timeLocation = loc
+	return c
+}
+
+func (c *Config) SetTimeGap(gap TimeGap) *Config {
+	c.timeGap = gap
+	return c
+}
+
+func (c *Config) SetPriceStyle(s PriceStyle) *Config {
+	c.priceStyle = s
+	return c
+}
+
+func (c *Config) SetVolumeProfile(rows int, r ProfileRange) *Config {
+	// volume profile of rows price buckets drawn on the right side of the candlestick chart
+	c.profileRows = rows
+	c.profileRange = r
+	return c
+}
+
+func (c *Config) SetValueArea(pct float64) *Config {
+	// portion of volume in the value area of volume profile, defaults to 0.7.
+	// Values out of (0, 1] are ignored
+	if pct > 0 && pct <= 1 {
+		c.valueArea = pct
+	}
+	return c
+}
+
+func (c *Config) SetHeikinAshi(enabled bool) *Config {
+	c.heikinAshi = enabled
+	return c
+}
+
+func (c *Config) SetHeikinAshiIndicators(enabled bool) *Config {
+	c.haIndicators = enabled
+	return c
+}
+
+func (c *Config) SetWarmUp(w WarmUp) *Config {
+	c.warmUp = w
+	return c
+}
+
+func (c *Config) SetCandleInterval(d time.Duration) *Config {
+	// used to locate empty slots when time gaps are shown and to locate events after the last candle,
+	// auto-detected as the min interval between candles if not set
+	c.candleInterval = d
+	return c
+}
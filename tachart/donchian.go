@@ -0,0 +1,75 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type donchian struct {
+	nm   string
+	n    int
+	opts indicatorOptions
+	ci   int
+}
+
+// NewDonchian creates Donchian channels overlay: highest high and lowest low of the last n periods,
+// with their midpoint in the middle.
+func NewDonchian(n int, options ...IndicatorOption) Indicator {
+	return &donchian{
+		nm:   fmt.Sprintf("DC(%v)", n),
+		n:    n,
+		opts: newIndicatorOptions(options),
+	}
+}
+
+func (d donchian) Name() string {
+	return d.nm
+}
+
+func (d donchian) YAxisLabel() string {
+	return ""
+}
+
+func (d donchian) YAxisMin() string {
+	return ""
+}
+
+func (d donchian) YAxisMax() string {
+	return ""
+}
+
+func (d donchian) GetNumColors() int {
+	return 2
+}
+
+func (d donchian) Lookback() int {
+	return d.n
+}
+
+func (d *donchian) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	d.ci = colorIndex
+	return channelTitles(d.nm, "Mid", top, left, d.ci, d.opts)
+}
+
+func (d donchian) GenChart(_, highs, lows, _, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	u := make([]float64, len(highs))
+	m := make([]float64, len(highs))
+	l := make([]float64, len(highs))
+	for i := range highs {
+		if i < d.n-1 {
+			u[i], m[i], l[i] = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+		u[i], l[i] = highs[i], lows[i]
+		for k := i - d.n + 1; k < i; k++ {
+			u[i] = math.Max(u[i], highs[k])
+			l[i] = math.Min(l[i], lows[k])
+		}
+		m[i] = (u[i] + l[i]) / 2
+	}
+
+	return genChannel(d.nm, "Mid", u, m, l, d.ci, d.opts, xAxis, gridIndex)
+}
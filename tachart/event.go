@@ -2,6 +2,7 @@ package tachart
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/otetz/go-tachart/opts"
 )
@@ -115,7 +116,54 @@ func (m EventMark) toEventStyle() *eventStyle {
 
 type Event struct {
 	Type        EventType
-	Label       string // x-axis label. Should match to one of the candles
-	Description string // any user-defined description wants to appear on tooltip
+	Label       string    // x-axis label. Should match to one of the candles
+	T           time.Time // event time, optional. If set, event is placed on the candle whose period contains T, instead of matching Label
+	Price       float64   // event price, optional. If set, event is marked at the price on candlestick chart instead of the event strip
+	Description string    // any user-defined description wants to appear on tooltip
 	EventMark   EventMark
 }
+
+func (e Event) style() *eventStyle {
+	if e.Type == CustomEvent {
+		return e.EventMark.toEventStyle()
+	}
+	return eventLabelMap[e.Type]
+}
+
+// stripMarkPoint returns the mark of the event at slot on the event strip.
+func (e Event) stripMarkPoint(slot int) opts.MarkPointNameCoordItem {
+	es := e.style()
+	return opts.MarkPointNameCoordItem{
+		Symbol:     "roundRect",
+		SymbolSize: es.symbolSize,
+		Coordinate: []interface{}{slot, 0},
+		Label:      es.label,
+		ItemStyle:  es.style,
+	}
+}
+
+// priceMarkPoint returns the mark of the event at slot and price on candlestick chart.
+// Longs are marked by an arrow pointing up to the price from below, shorts by an arrow
+// pointing down to the price from above.
+func (e Event) priceMarkPoint(slot int) opts.MarkPointNameCoordItem {
+	mp := e.stripMarkPoint(slot)
+	mp.Coordinate = []interface{}{slot, e.Price}
+	if e.Type != Long && e.Type != Short {
+		return mp
+	}
+
+	label := *mp.Label
+	mp.Label = &label
+	mp.Symbol = "arrow"
+	if e.Type == Long {
+		mp.SymbolOffset = []interface{}{0, "50%"}
+		label.Position = "bottom"
+	} else {
+		mp.SymbolRotate = 180
+		mp.SymbolOffset = []interface{}{0, "-50%"}
+		label.Position = "top"
+	}
+	// label font color is meant for the strip mark background
+	label.Color = mp.ItemStyle.Color
+	return mp
+}
@@ -0,0 +1,108 @@
+package tachart
+
+import (
+	"math"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+// BandFill fills the area between upper and lower series of a channel with a translucent color,
+// to be overlapped with the upper and lower lines. NaN values are left blank.
+func BandFill(name string, upper, lower []float64, color string, xAxis interface{}, gridIndex int) charts.Overlaper {
+	return genBandFill(name, upper, lower, color, color, xAxis, gridIndex)
+}
+
+// genBandFill fills the area between series a and b, with upColor where a >= b and downColor
+// otherwise. The fill is made of stacked lines: an invisible base line at min(a, b), with the
+// a-b and b-a spreads stacked on top of it as areas. Fill series are left out of tooltip.
+func genBandFill(name string, a, b []float64, upColor, downColor string, xAxis interface{}, gridIndex int) charts.Overlaper {
+	base := make([]float64, len(a))
+	up := make([]float64, len(a))
+	down := make([]float64, len(a))
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			base[i], up[i], down[i] = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+		base[i] = math.Min(a[i], b[i])
+		up[i] = math.Max(a[i]-b[i], 0)
+		down[i] = math.Max(b[i]-a[i], 0)
+	}
+
+	stack := name + "-Fill"
+	fill := func(nm string, vals []float64, areaColor string) *charts.Line {
+		seriesOpts := []charts.SeriesOpts{
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				Stack:      stack,
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color: "transparent",
+			}),
+			charts.WithSeriesTooltipOpts(opts.Tooltip{
+				Show: opts.Bool(false),
+			}),
+		}
+		if areaColor != "" {
+			seriesOpts = append(seriesOpts, charts.WithAreaStyleOpts(opts.AreaStyle{
+				Color:   areaColor,
+				Opacity: opacityFill,
+			}))
+		}
+		return charts.NewLine().
+			SetXAxis(xAxis).
+			AddSeries(nm, lineData(vals), seriesOpts...)
+	}
+
+	l := fill(stack+"-Base", base, "")
+	l.Overlap(fill(stack+"-Up", up, upColor), fill(stack+"-Down", down, downColor))
+	return l
+}
+
+// genChannel draws the middle, upper and lower lines of a channel overlay, with the upper and
+// lower lines sharing a color, and fills the channel if asked to by options.
+func genChannel(nm, middleNm string, u, m, l []float64, ci int, o indicatorOptions, xAxis interface{}, gridIndex int) charts.Overlaper {
+	line := func(nm string, vals []float64, ci int) *charts.Line {
+		return charts.NewLine().
+			SetXAxis(xAxis).
+			AddSeries(nm, lineData(vals),
+				charts.WithLineChartOpts(opts.LineChart{
+					Symbol:     "none",
+					XAxisIndex: gridIndex,
+					YAxisIndex: gridIndex,
+				}),
+				charts.WithLineStyleOpts(opts.LineStyle{
+					Color:   Color(ci),
+					Opacity: opacityMed,
+				}))
+	}
+
+	ml := line(nm+"-"+middleNm, m, ci)
+	ml.Overlap(line(nm+"-Upper", u, ci+1), line(nm+"-Lower", l, ci+1))
+	if o.fill {
+		color := o.fillColor
+		if color == "" {
+			color = Color(ci + 1)
+		}
+		ml.Overlap(BandFill(nm, u, l, color, xAxis, gridIndex))
+	}
+	return ml
+}
+
+// channelTitles returns legend titles of a channel overlay drawn by genChannel. Upper and lower
+// lines share a title if the channel is filled, otherwise they are titled separately.
+func channelTitles(nm, middleNm string, top, left int, ci int, o indicatorOptions) []opts.Title {
+	titles := []opts.Title{
+		LegendTitle(nm+"-"+middleNm, top, left, ci),
+	}
+	if o.fill {
+		return append(titles, LegendTitle(nm+"-Upper/Lower", top+chartLabelFontHeight, left, ci+1))
+	}
+	return append(titles,
+		LegendTitle(nm+"-Upper", top+chartLabelFontHeight, left, ci+1),
+		LegendTitle(nm+"-Lower", top+2*chartLabelFontHeight, left, ci+1),
+	)
+}
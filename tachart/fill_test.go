@@ -0,0 +1,57 @@
+package tachart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/otetz/go-tachart/opts"
+)
+
+func TestBandFill(t *testing.T) {
+	c := New(*NewConfig().AddOverlay(NewBBandsSMA(5, 2, WithBandFill(""))))
+	chart, err := c.genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	fills := 0
+	for _, s := range chart.MultiSeries {
+		if s.Stack == "" {
+			continue
+		}
+		fills++
+		assert.False(t, bool(*s.Tooltip.Show), s.Name)
+		data := s.Data.([]opts.LineData)
+		assert.Equal(t, len(testCdls), len(data))
+		assert.Equal(t, "-", data[3].Value, s.Name)
+		assert.NotEqual(t, "-", data[4].Value, s.Name)
+	}
+	assert.Equal(t, 3, fills)
+
+	titles := func(ind Indicator) []string {
+		ret := []string{}
+		for _, t := range ind.GetTitleOpts(0, 0, 0) {
+			ret = append(ret, t.Title)
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"BBANDS(SMA, 5)-Ma", "BBANDS(SMA, 5)-Upper", "BBANDS(SMA, 5)-Lower"}, titles(NewBBandsSMA(5, 2)))
+	assert.Equal(t, []string{"BBANDS(SMA, 5)-Ma", "BBANDS(SMA, 5)-Upper/Lower"}, titles(NewBBandsSMA(5, 2, WithBandFill(""))))
+}
+
+func TestChannels(t *testing.T) {
+	cfg := NewConfig().AddOverlay(NewKeltner(3, 4, 2, WithBandFill("")), NewDonchian(3))
+	chart, err := New(*cfg).genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	upper := seriesValues(t, chart.MultiSeries, "KC(3,4,2)-Upper")
+	assert.Equal(t, "-", upper[3])
+	assert.Greater(t, upper[4], seriesValues(t, chart.MultiSeries, "KC(3,4,2)-Ma")[4])
+	assert.Len(t, seriesValues(t, chart.MultiSeries, "KC(3,4,2)-Fill-Up"), len(testCdls))
+
+	upper = seriesValues(t, chart.MultiSeries, "DC(3)-Upper")
+	lower := seriesValues(t, chart.MultiSeries, "DC(3)-Lower")
+	assert.Equal(t, "-", upper[1])
+	assert.Equal(t, 2362.94, upper[2])
+	assert.Equal(t, 2287.3, lower[2])
+	assert.Equal(t, (2362.94+2287.3)/2, seriesValues(t, chart.MultiSeries, "DC(3)-Mid")[2])
+}
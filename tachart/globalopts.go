@@ -26,15 +26,14 @@ type globalOptsData struct {
 	dataZooms   []opts.DataZoom
 }
 
-func (c globalOptsData) genOpts(cfg Config, n int, eventDescMap map[string]string) []charts.GlobalOpts {
+func (c globalOptsData) genOpts(cfg Config, n int, eventDescMap map[int]string, ohlcMap map[int][]float64, rangeSpans [][]interface{}) []charts.GlobalOpts {
 	tooltip := c.tooltip
-	tooltip.Formatter = types.FuncStr(strings.Replace(string(tooltip.Formatter), "__EVENT_MAP__", toJson(eventDescMap), 1))
+	formatter := strings.Replace(string(tooltip.Formatter), "__EVENT_MAP__", toJson(eventDescMap), 1)
+	formatter = strings.Replace(formatter, "__OHLC_MAP__", toJson(ohlcMap), 1)
+	formatter = strings.Replace(formatter, "__RANGE_EVENTS__", toJson(rangeSpans), 1)
+	tooltip.Formatter = types.FuncStr(formatter)
 
-	numBars := (cfg.layout.chartWidth - left - right) / defaultCandleBarWidth
-	pct := float32(numBars*100) / float32(n)
-	if pct == 0 {
-		pct = 0.1
-	}
+	pct := zoomPercent(cfg, n)
 	dataZooms := []opts.DataZoom{}
 	for _, dz := range c.dataZooms {
 		dz.Start = dz.End - pct
@@ -53,6 +52,17 @@ func (c globalOptsData) genOpts(cfg Config, n int, eventDescMap map[string]strin
 	}
 }
 
+// zoomPercent returns the percentage of n slots shown in the initial dataZoom window,
+// which fits candles of default width.
+func zoomPercent(cfg Config, n int) float32 {
+	numBars := (cfg.layout.chartWidth - cfg.leftMargin - cfg.rightMargin) / defaultCandleBarWidth
+	pct := float32(numBars*100) / float32(n)
+	if pct == 0 {
+		pct = 0.1
+	}
+	return pct
+}
+
 func toJson(o interface{}) string {
 	buf := new(bytes.Buffer)
 	enc := json.NewEncoder(buf)
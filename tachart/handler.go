@@ -0,0 +1,78 @@
+package tachart
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/otetz/go-tachart/assets"
+)
+
+const (
+	// assets path relative to the chart page served by Handler
+	handlerAssetsDir = "assets/"
+)
+
+// CandleProvider returns the candles and events to render for the given request.
+type CandleProvider func(r *http.Request) ([]Candle, []Event, error)
+
+// Handler serves an interactive chart rendered per request along with the bundled assets.
+//
+// Chart page is served on any path ending with "/" (other paths are redirected),
+// and assets are served from "assets/" relative to the chart page, e.g.
+//
+//	http.Handle("/chart/", http.StripPrefix("/chart", tachart.NewHandler(*cfg, provider)))
+type Handler struct {
+	chart    *TAChart
+	provider CandleProvider
+}
+
+// NewHandler creates a Handler. Assets host of cfg is overridden by the embedded assets.
+func NewHandler(cfg Config, provider CandleProvider) *Handler {
+	cfg.assetsHost = handlerAssetsDir
+	return &Handler{
+		chart:    New(cfg),
+		provider: provider,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.Path
+	if i := strings.LastIndex(p, "/"+handlerAssetsDir); i >= 0 {
+		http.ServeFileFS(w, r, assets.FS, p[i+len(handlerAssetsDir)+1:])
+		return
+	}
+
+	if !strings.HasSuffix(p, "/") {
+		// assets are referred relatively, make sure they are resolved under current path
+		u := path.Base(p) + "/"
+		if r.URL.RawQuery != "" {
+			u += "?" + r.URL.RawQuery
+		}
+		// NOTE: http.Redirect resolves relative url against r.URL.Path, which doesn't work with http.StripPrefix
+		w.Header().Set("Location", u)
+		w.WriteHeader(http.StatusMovedPermanently)
+		return
+	}
+
+	cdls, events, err := h.provider(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	content, err := h.chart.RenderContent(cdls, events)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(content)
+}
+
+// NewAssetsHandler returns a handler serving the embedded assets, e.g. echarts.min.js and themes/*.js.
+// It can be used along with Config.SetAssetsHost to serve assets of static charts.
+func NewAssetsHandler() http.Handler {
+	return http.FileServer(http.FS(assets.FS))
+}
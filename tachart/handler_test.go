@@ -0,0 +1,45 @@
+package tachart
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(*testConfig().SetTheme(ThemeVintage), func(r *http.Request) ([]Candle, []Event, error) {
+		if r.URL.Query().Get("fail") != "" {
+			return nil, nil, errors.New("no candles")
+		}
+		return testCdls, testEvents, nil
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/chart/", http.StripPrefix("/chart", h))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/chart/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<script src="assets/echarts.min.js"></script>`)
+	assert.Contains(t, w.Body.String(), `<script src="assets/themes/vintage.js"></script>`)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/chart/assets/echarts.min.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/chart/assets/themes/vintage.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/chart/btc?tf=1h", nil))
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "btc/?tf=1h", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/chart/?fail=1", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,138 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type ichimoku struct {
+	nm      string
+	tenkan  int
+	kijun   int
+	senkouB int
+	ci      int
+}
+
+// NewIchimoku creates Ichimoku Kinko Hyo overlay, e.g. NewIchimoku(9, 26, 52).
+// Senkou spans are displaced forward and chikou backward by the kijun period.
+func NewIchimoku(tenkan, kijun, senkouB int) Indicator {
+	return &ichimoku{
+		nm:      fmt.Sprintf("Ichimoku(%v,%v,%v)", tenkan, kijun, senkouB),
+		tenkan:  tenkan,
+		kijun:   kijun,
+		senkouB: senkouB,
+	}
+}
+
+func (c ichimoku) Name() string {
+	return c.nm
+}
+
+func (c ichimoku) YAxisLabel() string {
+	return ""
+}
+
+func (c ichimoku) YAxisMin() string {
+	return ""
+}
+
+func (c ichimoku) YAxisMax() string {
+	return ""
+}
+
+func (c ichimoku) GetNumColors() int {
+	return 5
+}
+
+func (c ichimoku) Displacement() int {
+	return c.kijun
+}
+
+func (c *ichimoku) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	c.ci = colorIndex
+	titles := []opts.Title{}
+	for i, nm := range []string{"Tenkan", "Kijun", "SenkouA", "SenkouB", "Chikou"} {
+		titles = append(titles, LegendTitle(c.nm+"-"+nm, top+i*chartLabelFontHeight, left, c.ci+i))
+	}
+	return titles
+}
+
+// noBackfill excludes senkou spans and the cloud, which are blank over the displacement.
+func (c ichimoku) noBackfill(seriesName string) bool {
+	return strings.HasPrefix(seriesName, c.nm+"-Senkou") || strings.HasPrefix(seriesName, c.nm+"-Cloud")
+}
+
+func (c ichimoku) GenChart(_, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	n := len(closes)
+	d := c.kijun
+
+	tenkan := midpoints(highs, lows, c.tenkan)
+	kijun := midpoints(highs, lows, c.kijun)
+	b := midpoints(highs, lows, c.senkouB)
+
+	// senkou spans are plotted d slots ahead, chikou d slots behind
+	senkouA := make([]float64, n+d)
+	senkouB := make([]float64, n+d)
+	for i := 0; i < d; i++ {
+		senkouA[i] = math.NaN()
+		senkouB[i] = math.NaN()
+	}
+	for i := 0; i < n; i++ {
+		senkouA[i+d] = (tenkan[i] + kijun[i]) / 2
+		senkouB[i+d] = b[i]
+	}
+	chikou := make([]float64, n)
+	for i := 0; i < n; i++ {
+		chikou[i] = math.NaN()
+		if i+d < n {
+			chikou[i] = closes[i+d]
+		}
+	}
+
+	line := func(nm string, vals []float64, ci int) *charts.Line {
+		return charts.NewLine().
+			SetXAxis(xAxis).
+			AddSeries(c.nm+"-"+nm, lineData(vals),
+				charts.WithLineChartOpts(opts.LineChart{
+					Symbol:     "none",
+					XAxisIndex: gridIndex,
+					YAxisIndex: gridIndex,
+				}),
+				charts.WithLineStyleOpts(opts.LineStyle{
+					Color:   Color(ci),
+					Opacity: opacityMed,
+				}))
+	}
+
+	l := line("Tenkan", tenkan, c.ci)
+	l.Overlap(
+		line("Kijun", kijun, c.ci+1),
+		line("SenkouA", senkouA, c.ci+2),
+		line("SenkouB", senkouB, c.ci+3),
+		line("Chikou", chikou, c.ci+4),
+		genBandFill(c.nm+"-Cloud", senkouA, senkouB, colorUpBar, colorDownBar, xAxis, gridIndex),
+	)
+	return l
+}
+
+// midpoints returns (highest high + lowest low) / 2 of the last n periods, NaN until n periods are available.
+func midpoints(highs, lows []float64, n int) []float64 {
+	vals := make([]float64, len(highs))
+	for i := range highs {
+		if i < n-1 {
+			vals[i] = math.NaN()
+			continue
+		}
+		h, l := highs[i], lows[i]
+		for k := i - n + 1; k < i; k++ {
+			h = math.Max(h, highs[k])
+			l = math.Min(l, lows[k])
+		}
+		vals[i] = (h + l) / 2
+	}
+	return vals
+}
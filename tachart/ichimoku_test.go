@@ -0,0 +1,48 @@
+package tachart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIchimoku(t *testing.T) {
+	c := New(*NewConfig().AddOverlay(NewIchimoku(3, 5, 10)))
+	chart, err := c.genChart(testCdls, nil)
+	assert.NoError(t, err)
+	chart.Validate()
+
+	n := len(testCdls)
+	xAxis := chart.XAxisList[0].Data.([]string)
+	assert.Equal(t, n+5, len(xAxis))
+	assert.Equal(t, []string{"+1", "+2", "+3", "+4", "+5"}, xAxis[n:])
+
+	lens := map[string]int{}
+	for _, s := range chart.MultiSeries {
+		lens[s.Name] = sliceLen(s.Data)
+		if s.Stack != "" {
+			assert.False(t, bool(*s.Tooltip.Show), s.Name)
+		}
+	}
+	assert.Equal(t, n, lens["kline"])
+	assert.Equal(t, n, lens["Ichimoku(3,5,10)-Tenkan"])
+	assert.Equal(t, n+5, lens["Ichimoku(3,5,10)-SenkouA"])
+	assert.Equal(t, n+5, lens["Ichimoku(3,5,10)-Cloud-Fill-Up"])
+}
+
+func TestIchimokuShowGaps(t *testing.T) {
+	cdls := hourlyCandles("2021-06-04 14:00", "2021-06-04 15:00", "2021-06-04 18:00")
+	cfg := NewConfig().SetTimeGap(GapShow).SetTimeFormat("15:04").AddOverlay(NewIchimoku(1, 2, 3))
+	chart, err := New(*cfg).genChart(cdls, nil)
+	assert.NoError(t, err)
+	chart.Validate()
+
+	xAxis := chart.XAxisList[0].Data.([]string)
+	assert.Equal(t, []string{"14:00", "15:00", "16:00", "17:00", "18:00", "19:00", "20:00"}, xAxis)
+	for _, s := range chart.MultiSeries {
+		if s.Name == "events" {
+			continue
+		}
+		assert.Equal(t, len(xAxis), sliceLen(s.Data), s.Name)
+	}
+}
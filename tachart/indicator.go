@@ -1,6 +1,9 @@
 package tachart
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/otetz/go-tachart/charts"
 	"github.com/otetz/go-tachart/opts"
 )
@@ -10,19 +13,136 @@ const (
 	chartLabelFontHeight = 13
 )
 
+// WarmUp controls how indicator values not available yet, during the warm-up period
+// of indicators, are drawn.
+type WarmUp byte
+
+const (
+	// values are left blank
+	WarmUpGap WarmUp = iota
+	// values are back-filled with the first available value
+	WarmUpBackfill
+)
+
+// Indicator is the contract of an overlay (drawn on the candlestick grid) or
+// an indicator (drawn on its own grid below the candlesticks).
+// Implementations outside of this package can be added via Config.AddOverlay
+// and Config.AddIndicator.
+//
+// GetTitleOpts is always called before GenChart, so the color index passed to
+// GetTitleOpts can be kept (with a pointer receiver) and reused by GenChart.
 type Indicator interface {
 	// indicator name
-	name() string
-	// y axis label formatter
-	yAxisLabel() string
-	// y axis min label formatter
-	yAxisMin() string
-	// y axis max label formatter
-	yAxisMax() string
+	Name() string
+	// y axis label formatter, empty string to use default. Ignored for overlays
+	YAxisLabel() string
+	// y axis min label formatter, empty string to use default. Ignored for overlays
+	YAxisMin() string
+	// y axis max label formatter, empty string to use default. Ignored for overlays
+	YAxisMax() string
 	// # of colors needed
-	getNumColors() int
+	GetNumColors() int
 	// indicator chart legend config
-	getTitleOpts(top, left int, colorIndex int) []opts.Title
-	// indicator chart config
-	genChart(opens, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper
+	GetTitleOpts(top, left int, colorIndex int) []opts.Title
+	// indicator chart config, all series should be bound to gridIndex via XAxisIndex and YAxisIndex
+	GenChart(opens, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper
+}
+
+// Displacer is optionally implemented by indicators plotting values ahead of the last candle,
+// e.g. the senkou spans of Ichimoku. The x axis is extended with Displacement() empty slots
+// after the last candle, and series of such indicators may be longer than candles by up to
+// Displacement() values.
+type Displacer interface {
+	Displacement() int
+}
+
+// CandleIndicator is optionally implemented by indicators needing more than the price and
+// volume series, e.g. candle time to find trading sessions. GenCandleChart is called instead
+// of GenChart with the candles charted, whose labels are filled as shown on x axis.
+type CandleIndicator interface {
+	GenCandleChart(cdls []Candle, xAxis interface{}, gridIndex int) charts.Overlaper
+}
+
+// Lookbacker is optionally implemented by indicators whose value at a candle only depends on the
+// last Lookback() candles up to it, with the first Lookback()-1 values blank, e.g. SMA. Pushes to
+// a Stream recompute such indicators from the candles changed only. Lookback() < 1 means values
+// depend on all prior candles, e.g. EMA.
+type Lookbacker interface {
+	Lookback() int
+}
+
+// anchoredIndicator is implemented by indicators starting from an anchor candle, e.g. anchored VWAP.
+// Anchors are matched to candles like events.
+type anchoredIndicator interface {
+	anchorEvent() (Event, bool)
+}
+
+// backfillExcluder is implemented by indicators with series whose leading blanks are not warm-up
+// values, e.g. lines starting from an anchor or displaced forward. Such series are not back-filled.
+type backfillExcluder interface {
+	noBackfill(seriesName string) bool
+}
+
+// Color returns the i-th color of the palette used by indicator lines and legend titles.
+func Color(i int) string {
+	return colors[i%len(colors)]
+}
+
+// LegendTitle returns an indicator legend title in the style of built-in indicators.
+func LegendTitle(name string, top, left int, colorIndex int) opts.Title {
+	return opts.Title{
+		TitleStyle: &opts.TextStyle{
+			Color:    Color(colorIndex),
+			FontSize: chartLabelFontSize,
+		},
+		Title: name,
+		Left:  px(left),
+		Top:   px(top),
+	}
+}
+
+// LegendTitleHeight is the vertical space taken by one legend title.
+func LegendTitleHeight() int {
+	return chartLabelFontHeight
+}
+
+// YLabelFormatterFunc returns a y axis label formatter showing dp decimal places.
+func YLabelFormatterFunc(dp int) string {
+	return strings.Replace(yLabelFormatterFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", dp), -1)
+}
+
+// MinRoundFunc returns a y axis min formatter slightly below the data min, rounded to dp decimal places.
+func MinRoundFunc(dp int) string {
+	return strings.Replace(minRoundFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", dp), -1)
+}
+
+// MaxRoundFunc returns a y axis max formatter slightly above the data max, rounded to dp decimal places.
+func MaxRoundFunc(dp int) string {
+	return strings.Replace(maxRoundFuncTpl, "__DECIMAL_PLACES__", fmt.Sprintf("%v", dp), -1)
+}
+
+// CompactLabelFormatterFunc returns a y axis label formatter abbreviating large values, e.g. 1.5M.
+func CompactLabelFormatterFunc() string {
+	return compactLabelFormatterFunc
+}
+
+// MinPadFunc returns a y axis min formatter 5% of the data range below the data min,
+// which works for negative values as well.
+func MinPadFunc() string {
+	return minPadFunc
+}
+
+// MaxPadFunc returns a y axis max formatter 5% of the data range above the data max.
+func MaxPadFunc() string {
+	return maxPadFunc
+}
+
+// FixedValueFunc returns a y axis min/max formatter pinned to v.
+func FixedValueFunc(v float64) string {
+	return fmt.Sprintf("function(value) { return %v }", v)
+}
+
+// Decimals returns a reasonable # of decimal places to show values of the given series.
+func Decimals(arr ...[]float64) int {
+	return decimals(arr...)
 }
@@ -0,0 +1,77 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/iamjinlei/go-tart"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type keltner struct {
+	nm   string
+	emaN int64
+	atrN int64
+	mult float64
+	opts indicatorOptions
+	ci   int
+}
+
+// NewKeltner creates Keltner channels overlay: EMA(emaN) of close, with bands mult times ATR(atrN) away,
+// e.g. NewKeltner(20, 10, 2).
+func NewKeltner(emaN, atrN int, mult float64, options ...IndicatorOption) Indicator {
+	return &keltner{
+		nm:   fmt.Sprintf("KC(%v,%v,%v)", emaN, atrN, mult),
+		emaN: int64(emaN),
+		atrN: int64(atrN),
+		mult: mult,
+		opts: newIndicatorOptions(options),
+	}
+}
+
+func (k keltner) Name() string {
+	return k.nm
+}
+
+func (k keltner) YAxisLabel() string {
+	return ""
+}
+
+func (k keltner) YAxisMin() string {
+	return ""
+}
+
+func (k keltner) YAxisMax() string {
+	return ""
+}
+
+func (k keltner) GetNumColors() int {
+	return 2
+}
+
+func (k *keltner) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	k.ci = colorIndex
+	return channelTitles(k.nm, "Ma", top, left, k.ci, k.opts)
+}
+
+func (k keltner) GenChart(_, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	m := tart.EmaArr(closes, k.emaN)
+	atr := tart.AtrArr(highs, lows, closes, k.atrN)
+
+	// EMA is available after emaN periods, ATR after atrN true ranges
+	warmUp := int(math.Max(float64(k.emaN-1), float64(k.atrN)))
+	u := make([]float64, len(closes))
+	l := make([]float64, len(closes))
+	for i := range closes {
+		if i < warmUp {
+			u[i], m[i], l[i] = math.NaN(), math.NaN(), math.NaN()
+			continue
+		}
+		u[i] = m[i] + k.mult*atr[i]
+		l[i] = m[i] - k.mult*atr[i]
+	}
+
+	return genChannel(k.nm, "Ma", u, m, l, k.ci, k.opts, xAxis, gridIndex)
+}
@@ -0,0 +1,91 @@
+package tachart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+const (
+	colorLevel = "#808080"
+	colorPivot = "#FFA500"
+)
+
+// LevelStyle is the line style of a price level.
+type LevelStyle struct {
+	Color string  // line and label color, gray if empty
+	Type  string  // "solid", "dashed" or "dotted", solid if empty
+	Width float32 // line width, optional
+}
+
+// level is a horizontal line across the candlestick chart, e.g. a support or a resistance.
+type level struct {
+	price float64
+	label string
+	style LevelStyle
+}
+
+// genLevelChart draws levels across the candlestick chart, and pivot levels of each period from its
+// first to its last candle. slots are the x axis slots of cdls.
+func genLevelChart(levels []level, pivots []pivots, cdls []Candle, slots []int, dp int) charts.Overlaper {
+	lines := []opts.MarkLineNameYAxisItem{}
+	for _, lvl := range levels {
+		color := lvl.style.Color
+		if color == "" {
+			color = colorLevel
+		}
+		lines = append(lines, opts.MarkLineNameYAxisItem{
+			Name:  lvl.label,
+			YAxis: lvl.price,
+			Label: &opts.Label{
+				Show:      opts.Bool(true),
+				Color:     color,
+				Formatter: strings.TrimSpace(fmt.Sprintf("%v %.*f", lvl.label, dp, lvl.price)),
+			},
+			LineStyle: &opts.LineStyle{
+				Color: color,
+				Type:  lvl.style.Type,
+				Width: lvl.style.Width,
+			},
+		})
+	}
+
+	segments := []opts.MarkLineNameCoordItem{}
+	for _, pv := range pivots {
+		for _, period := range pv.periods(cdls) {
+			for _, pl := range period.levels {
+				color := colorPivot
+				switch pl.name[0] {
+				case 'R':
+					color = colorDownBar
+				case 'S':
+					color = colorUpBar
+				}
+				segments = append(segments, opts.MarkLineNameCoordItem{
+					Coordinate0: []interface{}{slots[period.first], pl.price},
+					Coordinate1: []interface{}{slots[period.last], pl.price},
+					Label: &opts.Label{
+						Show:      opts.Bool(true),
+						Color:     color,
+						Formatter: pl.name,
+					},
+					LineStyle: &opts.LineStyle{
+						Color:   color,
+						Type:    "dashed",
+						Opacity: opacityMed,
+					},
+				})
+			}
+		}
+	}
+
+	return charts.NewScatter().
+		AddSeries("levels", []opts.ScatterData{},
+			charts.WithMarkLineNameYAxisItemOpts(lines...),
+			charts.WithMarkLineNameCoordItemOpts(segments...),
+			charts.WithMarkLineStyleOpts(opts.MarkLineStyle{
+				Symbol: []string{"none", "none"},
+			}))
+}
@@ -0,0 +1,68 @@
+package tachart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPivotLevels(t *testing.T) {
+	prices := func(levels []pivotLevel) map[string]float64 {
+		ret := map[string]float64{}
+		for _, l := range levels {
+			ret[l.name] = l.price
+		}
+		return ret
+	}
+	assert.Equal(t, map[string]float64{"R3": 20, "R2": 15, "R1": 10, "P": 5, "S1": 0, "S2": -5, "S3": -10},
+		prices(PivotClassic.levels(10, 0, 5)))
+	assert.InDeltaMapValues(t, map[string]float64{"R3": 15, "R2": 11.18, "R1": 8.82, "P": 5, "S1": 1.18, "S2": -1.18, "S3": -5},
+		prices(PivotFibonacci.levels(10, 0, 5)), 1e-9)
+	assert.InDeltaMapValues(t, map[string]float64{"R4": 5 + 5.5, "R3": 5 + 2.75, "R2": 5 + 11.0/6, "R1": 5 + 11.0/12, "P": 5,
+		"S1": 5 - 11.0/12, "S2": 5 - 11.0/6, "S3": 5 - 2.75, "S4": 5 - 5.5},
+		prices(PivotCamarilla.levels(10, 0, 5)), 1e-9)
+	assert.Equal(t, map[string]float64{"R2": 16, "R1": 12, "P": 6, "S1": 2, "S2": -4},
+		prices(PivotWoodie.levels(10, 0, 7)))
+}
+
+func TestPivotPeriods(t *testing.T) {
+	cdls := hourlyCandles("2021-06-03 22:00", "2021-06-03 23:00", "2021-06-04 00:00", "2021-06-04 01:00", "2021-06-07 09:00")
+	cdls[0].H, cdls[1].L, cdls[1].C = 10, 0, 5
+
+	periods := pivots{method: PivotClassic, boundary: DailySession(time.UTC)}.periods(cdls)
+	assert.Len(t, periods, 2)
+	assert.Equal(t, 2, periods[0].first)
+	assert.Equal(t, 3, periods[0].last)
+	assert.Equal(t, pivotLevel{"P", 5}, periods[0].levels[3])
+	assert.Equal(t, 4, periods[1].first)
+	assert.Equal(t, 4, periods[1].last)
+
+	periods = pivots{method: PivotClassic, boundary: WeeklySession(time.UTC)}.periods(cdls)
+	assert.Len(t, periods, 1)
+	assert.Equal(t, 4, periods[0].first)
+	assert.Empty(t, pivots{method: PivotClassic, boundary: MonthlySession(nil)}.periods(cdls))
+	// nil location is UTC
+	assert.Len(t, pivots{method: PivotClassic, boundary: WeeklySession(nil)}.periods(cdls), 1)
+}
+
+func TestLevelChart(t *testing.T) {
+	cdls := hourlyCandles("2021-06-03 22:00", "2021-06-03 23:00", "2021-06-04 00:00", "2021-06-04 01:00")
+	cfg := NewConfig().
+		AddLevel(1.8, "resistance", LevelStyle{Color: "#FF0000", Type: "dotted"}).
+		AddPivots(PivotWoodie, DailySession(time.UTC))
+	chart, err := New(*cfg).genChart(cdls, nil)
+	assert.NoError(t, err)
+
+	found := false
+	for _, s := range chart.MultiSeries {
+		if s.Name == "levels" {
+			found = true
+			assert.Len(t, s.MarkLines.Data, 1+5)
+			assert.Contains(t, toJson(s.MarkLines.Data[0]), `"formatter":"resistance 1.80"`)
+			assert.Contains(t, toJson(s.MarkLines.Data[1]), `"coord":[2,`)
+			assert.Contains(t, toJson(s.MarkLines.Data[1]), `"coord":[3,`)
+		}
+	}
+	assert.True(t, found)
+}
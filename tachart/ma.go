@@ -2,6 +2,8 @@ package tachart
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/iamjinlei/go-tart"
 
@@ -10,49 +12,108 @@ import (
 )
 
 type ma struct {
-	nm string
-	n  int64
-	fn func([]float64, int64) []float64
-	ci int
+	nm         string
+	n          int64
+	fn         func([]float64, int64) []float64
+	initPeriod int  // # of leading values not available
+	windowed   bool // values only depend on the last initPeriod+1 values
+	src        Source
+	ci         int
 }
 
-func NewSMA(n int) Indicator {
+func newMA(name string, params []interface{}, n int, fn func([]float64, int64) []float64, initPeriod int, options []IndicatorOption) Indicator {
+	o := newIndicatorOptions(options)
+	if o.src != SourceClose {
+		params = append(params, o.src)
+	}
+	strs := []string{}
+	for _, p := range params {
+		strs = append(strs, fmt.Sprint(p))
+	}
 	return &ma{
-		nm: fmt.Sprintf("SMA(%v)", n),
-		n:  int64(n),
-		fn: tart.SmaArr,
+		nm:         fmt.Sprintf("%v(%v)", name, strings.Join(strs, ",")),
+		n:          int64(n),
+		fn:         fn,
+		initPeriod: initPeriod,
+		src:        o.src,
 	}
 }
 
-func NewEMA(n int) Indicator {
-	return &ma{
-		nm: fmt.Sprintf("EMA(%v)", n),
-		n:  int64(n),
-		fn: tart.EmaArr,
+func NewSMA(n int, options ...IndicatorOption) Indicator {
+	return windowedMA(newMA("SMA", []interface{}{n}, n, tart.SmaArr, n-1, options))
+}
+
+func NewEMA(n int, options ...IndicatorOption) Indicator {
+	return newMA("EMA", []interface{}{n}, n, tart.EmaArr, n-1, options)
+}
+
+// NewWMA creates weighted moving average overlay.
+func NewWMA(n int, options ...IndicatorOption) Indicator {
+	return windowedMA(newMA("WMA", []interface{}{n}, n, tart.WmaArr, n-1, options))
+}
+
+// NewDEMA creates double exponential moving average overlay.
+func NewDEMA(n int, options ...IndicatorOption) Indicator {
+	return newMA("DEMA", []interface{}{n}, n, tart.DemaArr, 2*n-2, options)
+}
+
+// NewTEMA creates triple exponential moving average overlay.
+func NewTEMA(n int, options ...IndicatorOption) Indicator {
+	return newMA("TEMA", []interface{}{n}, n, tart.TemaArr, 3*n-3, options)
+}
+
+// NewKAMA creates Kaufman adaptive moving average overlay.
+func NewKAMA(n int, options ...IndicatorOption) Indicator {
+	return newMA("KAMA", []interface{}{n}, n, tart.KamaArr, n, options)
+}
+
+// NewHMA creates Hull moving average overlay: WMA(sqrt(n)) of 2*WMA(n/2) - WMA(n).
+func NewHMA(n int, options ...IndicatorOption) Indicator {
+	return windowedMA(newMA("HMA", []interface{}{n}, n, hmaArr, n-1+hmaSqrtN(n)-1, options))
+}
+
+// NewT3 creates Tillson T3 moving average overlay, e.g. NewT3(5, 0.7).
+func NewT3(n int, vFactor float64, options ...IndicatorOption) Indicator {
+	fn := func(in []float64, n int64) []float64 {
+		return t3Arr(in, n, vFactor)
 	}
+	return newMA("T3", []interface{}{n, vFactor}, n, fn, 6*n-6, options)
 }
 
-func (c ma) name() string {
+// windowedMA marks a moving average of the last values only, unlike recursive ones like EMA.
+func windowedMA(ind Indicator) Indicator {
+	ind.(*ma).windowed = true
+	return ind
+}
+
+func (c ma) Name() string {
 	return c.nm
 }
 
-func (c ma) yAxisLabel() string {
+func (c ma) YAxisLabel() string {
 	return ""
 }
 
-func (c ma) yAxisMin() string {
+func (c ma) YAxisMin() string {
 	return ""
 }
 
-func (c ma) yAxisMax() string {
+func (c ma) YAxisMax() string {
 	return ""
 }
 
-func (c ma) getNumColors() int {
+func (c ma) GetNumColors() int {
 	return 1
 }
 
-func (c *ma) getTitleOpts(top, left int, colorIndex int) []opts.Title {
+func (c ma) Lookback() int {
+	if c.windowed {
+		return c.initPeriod + 1
+	}
+	return 0
+}
+
+func (c *ma) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
 	c.ci = colorIndex
 	return []opts.Title{
 		{
@@ -67,20 +128,13 @@ func (c *ma) getTitleOpts(top, left int, colorIndex int) []opts.Title {
 	}
 }
 
-func (c ma) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
-	ma := c.fn(closes, c.n)
-	for i := 0; i < int(c.n); i++ {
-		ma[i] = ma[c.n]
-	}
-
-	items := []opts.LineData{}
-	for _, v := range ma {
-		items = append(items, opts.LineData{Value: v})
-	}
+func (c ma) GenChart(opens, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	ma := c.fn(sourcePrices(c.src, opens, highs, lows, closes), c.n)
+	gapWarmUp(c.initPeriod, ma)
 
 	return charts.NewLine().
 		SetXAxis(xAxis).
-		AddSeries(c.nm, items,
+		AddSeries(c.nm, lineData(ma),
 			charts.WithLineChartOpts(opts.LineChart{
 				Symbol:     "none",
 				XAxisIndex: gridIndex,
@@ -91,3 +145,78 @@ func (c ma) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex
 				Opacity: opacityMed,
 			}))
 }
+
+func hmaSqrtN(n int) int {
+	return int(math.Sqrt(float64(n)))
+}
+
+// hmaArr computes Hull moving average, values not available are 0 as go-tart does.
+func hmaArr(in []float64, n int64) []float64 {
+	out := make([]float64, len(in))
+	half := tart.NewWma(n / 2)
+	full := tart.NewWma(n)
+	hull := tart.NewWma(int64(hmaSqrtN(int(n))))
+	for i, v := range in {
+		h := half.Update(v)
+		f := full.Update(v)
+		if !full.Valid() {
+			continue
+		}
+		out[i] = hull.Update(2*h - f)
+		if !hull.Valid() {
+			out[i] = 0
+		}
+	}
+	return out
+}
+
+// t3Arr computes Tillson T3 moving average, values not available are 0 as go-tart does.
+func t3Arr(in []float64, n int64, vFactor float64) []float64 {
+	a := vFactor
+	c1 := -a * a * a
+	c2 := 3*a*a + 3*a*a*a
+	c3 := -6*a*a - 3*a - 3*a*a*a
+	c4 := 1 + 3*a + a*a*a + 3*a*a
+
+	out := make([]float64, len(in))
+	emas := make([]*tart.Ema, 6)
+	for k := range emas {
+		emas[k] = tart.NewEma(n, 2.0/float64(n+1))
+	}
+	for i, v := range in {
+		// each EMA is fed once the previous one is available
+		e := make([]float64, len(emas))
+		valid := true
+		for k, ema := range emas {
+			e[k] = ema.Update(v)
+			if !ema.Valid() {
+				valid = false
+				break
+			}
+			v = e[k]
+		}
+		if valid {
+			out[i] = c1*e[5] + c2*e[4] + c3*e[3] + c4*e[2]
+		}
+	}
+	return out
+}
+
+// sourcePrices returns the prices indicators are computed on.
+func sourcePrices(src Source, opens, highs, lows, closes []float64) []float64 {
+	if src == SourceClose {
+		return closes
+	}
+	prices := make([]float64, len(closes))
+	for i := range closes {
+		switch src {
+		case SourceHL2:
+			prices[i] = (highs[i] + lows[i]) / 2
+		case SourceHLC3:
+			prices[i] = (highs[i] + lows[i] + closes[i]) / 3
+		case SourceOHLC4:
+			prices[i] = (opens[i] + highs[i] + lows[i] + closes[i]) / 4
+		}
+	}
+	return prices
+}
@@ -0,0 +1,44 @@
+package tachart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMANames(t *testing.T) {
+	assert.Equal(t, "SMA(5)", NewSMA(5).Name())
+	assert.Equal(t, "HMA(9,HLC3)", NewHMA(9, WithSource(SourceHLC3)).Name())
+	assert.Equal(t, "T3(5,0.7)", NewT3(5, 0.7).Name())
+	assert.Equal(t, "T3(5,0.7,OHLC4)", NewT3(5, 0.7, WithSource(SourceOHLC4)).Name())
+}
+
+func TestMAWarmUp(t *testing.T) {
+	in := []float64{}
+	for i := 0; i < 40; i++ {
+		in = append(in, 10)
+	}
+
+	hma := hmaArr(in, 9)
+	init := 9 - 1 + hmaSqrtN(9) - 1
+	assert.Equal(t, 0.0, hma[init-1])
+	assert.InDelta(t, 10, hma[init], 1e-9)
+
+	t3 := t3Arr(in, 5, 0.7)
+	init = 6*5 - 6
+	assert.Equal(t, 0.0, t3[init-1])
+	assert.InDelta(t, 10, t3[init], 1e-9)
+	assert.InDelta(t, 10, t3[len(t3)-1], 1e-9)
+}
+
+func TestSourcePrices(t *testing.T) {
+	opens, highs, lows, closes := []float64{1}, []float64{4}, []float64{2}, []float64{3}
+	assert.Equal(t, closes, sourcePrices(SourceClose, opens, highs, lows, closes))
+	assert.Equal(t, []float64{3}, sourcePrices(SourceHL2, opens, highs, lows, closes))
+	assert.Equal(t, []float64{3}, sourcePrices(SourceHLC3, opens, highs, lows, closes))
+	assert.Equal(t, []float64{2.5}, sourcePrices(SourceOHLC4, opens, highs, lows, closes))
+
+	cfg := NewConfig().AddOverlay(NewWMA(3), NewDEMA(3), NewTEMA(3), NewKAMA(3), NewHMA(4, WithSource(SourceHL2)), NewT3(3, 0.7))
+	_, err := New(*cfg).genChart(testCdls, nil)
+	assert.NoError(t, err)
+}
@@ -2,7 +2,7 @@ package tachart
 
 import (
 	"fmt"
-	"strings"
+	"math"
 
 	"github.com/iamjinlei/go-tart"
 
@@ -27,27 +27,27 @@ func NewMACD(fast, slow, signal int) Indicator {
 	}
 }
 
-func (c macd) name() string {
+func (c macd) Name() string {
 	return c.nm
 }
 
-func (c macd) yAxisLabel() string {
-	return strings.Replace(yLabelFormatterFuncTpl, "__DECIMAL_PLACES__", "0", -1)
+func (c macd) YAxisLabel() string {
+	return YLabelFormatterFunc(0)
 }
 
-func (c macd) yAxisMin() string {
-	return strings.Replace(minRoundFuncTpl, "__DECIMAL_PLACES__", "0", -1)
+func (c macd) YAxisMin() string {
+	return MinRoundFunc(0)
 }
 
-func (c macd) yAxisMax() string {
-	return strings.Replace(maxRoundFuncTpl, "__DECIMAL_PLACES__", "0", -1)
+func (c macd) YAxisMax() string {
+	return MaxRoundFunc(0)
 }
 
-func (c macd) getNumColors() int {
+func (c macd) GetNumColors() int {
 	return 2
 }
 
-func (c *macd) getTitleOpts(top, left int, colorIndex int) []opts.Title {
+func (c *macd) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
 	c.ci = colorIndex
 	return []opts.Title{
 		{
@@ -71,16 +71,13 @@ func (c *macd) getTitleOpts(top, left int, colorIndex int) []opts.Title {
 	}
 }
 
-func (c macd) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+func (c macd) GenChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
 	macd, signal, hist := tart.MacdArr(closes, c.fast, c.slow, c.signal)
-
-	lineItems := []opts.LineData{}
-	for _, v := range macd {
-		lineItems = append(lineItems, opts.LineData{Value: v})
-	}
+	// all values are available once the signal line is
+	gapWarmUp(int(c.slow+c.signal)-2, macd, signal, hist)
 	macdLine := charts.NewLine().
 		SetXAxis(xAxis).
-		AddSeries(c.nm+"-Diff", lineItems,
+		AddSeries(c.nm+"-Diff", lineData(macd),
 			charts.WithLineChartOpts(opts.LineChart{
 				Symbol:     "none",
 				XAxisIndex: gridIndex,
@@ -92,13 +89,9 @@ func (c macd) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridInde
 			}),
 		)
 
-	lineItems = []opts.LineData{}
-	for _, v := range signal {
-		lineItems = append(lineItems, opts.LineData{Value: v})
-	}
 	signalLine := charts.NewLine().
 		SetXAxis(xAxis).
-		AddSeries(c.nm+"-Sig", lineItems,
+		AddSeries(c.nm+"-Sig", lineData(signal),
 			charts.WithLineChartOpts(opts.LineChart{
 				Symbol:     "none",
 				XAxisIndex: gridIndex,
@@ -112,6 +105,10 @@ func (c macd) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridInde
 
 	barItems := []opts.BarData{}
 	for _, v := range hist {
+		if math.IsNaN(v) {
+			barItems = append(barItems, opts.BarData{Value: "-"})
+			continue
+		}
 		style := &opts.ItemStyle{
 			Color:   colorUpBar,
 			Opacity: opacityHeavy,
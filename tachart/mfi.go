@@ -0,0 +1,98 @@
+package tachart
+
+import (
+	"fmt"
+
+	"github.com/iamjinlei/go-tart"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type mfi struct {
+	nm         string
+	n          int64
+	oversold   float64
+	overbought float64
+	ci         int
+}
+
+// NewMFI creates money flow index indicator, e.g. NewMFI(14, 20, 80).
+func NewMFI(n int, oversold, overbought float64) Indicator {
+	return &mfi{
+		nm:         fmt.Sprintf("MFI(%v)", n),
+		n:          int64(n),
+		oversold:   oversold,
+		overbought: overbought,
+	}
+}
+
+func (m mfi) Name() string {
+	return m.nm
+}
+
+func (m mfi) YAxisLabel() string {
+	return YLabelFormatterFunc(0)
+}
+
+func (m mfi) YAxisMin() string {
+	return FixedValueFunc(0)
+}
+
+func (m mfi) YAxisMax() string {
+	return FixedValueFunc(100)
+}
+
+func (m mfi) GetNumColors() int {
+	return 1
+}
+
+// Lookback includes the candle before the period, money flow is signed by the change of typical price.
+func (m mfi) Lookback() int {
+	return int(m.n) + 1
+}
+
+func (m *mfi) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	m.ci = colorIndex
+	return []opts.Title{
+		LegendTitle(m.nm, top, left, m.ci),
+	}
+}
+
+func (m mfi) GenChart(_, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	vals := tart.MfiArr(highs, lows, closes, vols, m.n)
+	gapWarmUp(int(m.n), vals)
+
+	return charts.NewLine().
+		SetXAxis(xAxis).
+		AddSeries(m.nm, lineData(vals),
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color:   Color(m.ci),
+				Opacity: opacityMed,
+			}),
+			charts.WithMarkLineNameYAxisItemOpts(
+				opts.MarkLineNameYAxisItem{
+					Name:  "oversold",
+					YAxis: m.oversold,
+				},
+				opts.MarkLineNameYAxisItem{
+					Name:  "overbought",
+					YAxis: m.overbought,
+				},
+			),
+			charts.WithMarkLineStyleOpts(
+				opts.MarkLineStyle{
+					Symbol: []string{"none", "none"},
+					LineStyle: &opts.LineStyle{
+						Color:   colorDownBar,
+						Opacity: opacityMed,
+					},
+				},
+			),
+		)
+}
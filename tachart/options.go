@@ -0,0 +1,74 @@
+package tachart
+
+// Source is the price indicators are computed on.
+type Source byte
+
+const (
+	SourceClose Source = iota
+	SourceHL2          // (high + low) / 2
+	SourceHLC3         // (high + low + close) / 3
+	SourceOHLC4        // (open + high + low + close) / 4
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceHL2:
+		return "HL2"
+	case SourceHLC3:
+		return "HLC3"
+	case SourceOHLC4:
+		return "OHLC4"
+	}
+	return "Close"
+}
+
+// IndicatorOption configures optional behavior of built-in indicators.
+// Options not applicable to an indicator are ignored.
+type IndicatorOption func(*indicatorOptions)
+
+type indicatorOptions struct {
+	fill      bool
+	fillColor string
+	stdDevs   []float64
+	threshold *float64
+	src       Source
+}
+
+func newIndicatorOptions(options []IndicatorOption) indicatorOptions {
+	o := indicatorOptions{}
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBandFill fills the area between upper and lower bands of channel overlays, e.g. Bollinger Bands,
+// with a translucent color. Empty color means the color of the band lines.
+func WithBandFill(color string) IndicatorOption {
+	return func(o *indicatorOptions) {
+		o.fill = true
+		o.fillColor = color
+	}
+}
+
+// WithStdDevBands adds bands at the given # of standard deviations above and below the line
+// of overlays like VWAP, e.g. WithStdDevBands(1, 2).
+func WithStdDevBands(nStdDevs ...float64) IndicatorOption {
+	return func(o *indicatorOptions) {
+		o.stdDevs = append(o.stdDevs, nStdDevs...)
+	}
+}
+
+// WithThreshold draws a mark line at v on indicator panes, e.g. WithThreshold(25) for ADX.
+func WithThreshold(v float64) IndicatorOption {
+	return func(o *indicatorOptions) {
+		o.threshold = &v
+	}
+}
+
+// WithSource computes moving averages on the given price instead of close, e.g. WithSource(SourceHLC3).
+func WithSource(src Source) IndicatorOption {
+	return func(o *indicatorOptions) {
+		o.src = src
+	}
+}
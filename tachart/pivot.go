@@ -0,0 +1,97 @@
+package tachart
+
+import (
+	"math"
+)
+
+// PivotMethod is the formula of pivot points.
+type PivotMethod byte
+
+const (
+	// P = (H+L+C)/3, R1..R3 and S1..S3 from the prior range
+	PivotClassic PivotMethod = iota
+	// P = (H+L+C)/3, R and S at 38.2%, 61.8% and 100% of the prior range from P
+	PivotFibonacci
+	// R1..R4 and S1..S4 at 1.1/12, 1.1/6, 1.1/4 and 1.1/2 of the prior range from the close
+	PivotCamarilla
+	// P = (H+L+2C)/4, R1, R2, S1 and S2 like classic
+	PivotWoodie
+)
+
+// pivotLevel is a named price of pivot points, e.g. "R1".
+type pivotLevel struct {
+	name  string
+	price float64
+}
+
+// levels returns the pivot levels of a period from high, low and close of the prior period.
+func (m PivotMethod) levels(h, l, c float64) []pivotLevel {
+	r := h - l
+	p := (h + l + c) / 3
+	switch m {
+	case PivotFibonacci:
+		return []pivotLevel{
+			{"R3", p + r}, {"R2", p + 0.618*r}, {"R1", p + 0.382*r},
+			{"P", p},
+			{"S1", p - 0.382*r}, {"S2", p - 0.618*r}, {"S3", p - r},
+		}
+	case PivotCamarilla:
+		return []pivotLevel{
+			{"R4", c + r*1.1/2}, {"R3", c + r*1.1/4}, {"R2", c + r*1.1/6}, {"R1", c + r*1.1/12},
+			{"P", p},
+			{"S1", c - r*1.1/12}, {"S2", c - r*1.1/6}, {"S3", c - r*1.1/4}, {"S4", c - r*1.1/2},
+		}
+	case PivotWoodie:
+		p = (h + l + 2*c) / 4
+		return []pivotLevel{
+			{"R2", p + r}, {"R1", 2*p - l},
+			{"P", p},
+			{"S1", 2*p - h}, {"S2", p - r},
+		}
+	default:
+		return []pivotLevel{
+			{"R3", h + 2*(p-l)}, {"R2", p + r}, {"R1", 2*p - l},
+			{"P", p},
+			{"S1", 2*p - h}, {"S2", p - r}, {"S3", l - 2*(h-p)},
+		}
+	}
+}
+
+type pivots struct {
+	method   PivotMethod
+	boundary SessionBoundary
+}
+
+// pivotPeriod is the pivot levels of the candles first to last.
+type pivotPeriod struct {
+	first  int
+	last   int
+	levels []pivotLevel
+}
+
+// periods splits cdls at session boundaries and computes the pivot levels of each period from
+// the prior one. The first period has no levels, so it is not returned.
+func (pv pivots) periods(cdls []Candle) []pivotPeriod {
+	periods := []pivotPeriod{}
+	first := 0
+	var h, l, c float64
+	for i := range cdls {
+		if i+1 < len(cdls) && (pv.boundary == nil || !pv.boundary(cdls[i], cdls[i+1])) {
+			continue
+		}
+		if first > 0 {
+			periods = append(periods, pivotPeriod{
+				first:  first,
+				last:   i,
+				levels: pv.method.levels(h, l, c),
+			})
+		}
+		h, l, c = cdls[first].H, cdls[first].L, cdls[i].C
+		for _, cdl := range cdls[first : i+1] {
+			h = math.Max(h, cdl.H)
+			l = math.Min(l, cdl.L)
+		}
+		first = i + 1
+	}
+	return periods
+}
@@ -0,0 +1,65 @@
+package tachart
+
+import (
+	"math"
+)
+
+// PointFigure builds Point-and-Figure columns from closes of candles. Prices are rounded to boxes,
+// a column of Xs goes on while close rises by boxes, and reverses into a column of Os once close
+// falls by reversal boxes below the top X box, vice versa.
+//
+// Columns are returned as candles from the first to the last box of the column, so X columns are
+// drawn as up candles and O columns as down candles. Like Renko, column labels show the range of
+// candles spanned and columns hold the total volume of them.
+func PointFigure(cdls []Candle, box BoxSize, reversal int) []Candle {
+	size := box(cdls)
+	if len(cdls) == 0 || !(size > 0) || reversal < 1 {
+		return nil
+	}
+	// a small epsilon keeps prices right on box boundaries from being rounded off by float errors
+	floor := func(v float64) float64 { return math.Floor(v/size+1e-9) * size }
+	ceil := func(v float64) float64 { return math.Ceil(v/size-1e-9) * size }
+
+	spans := []candleSpan{}
+	add := func(o, c float64, first int) {
+		spans = append(spans, candleSpan{
+			Candle: Candle{O: o, H: math.Max(o, c), L: math.Min(o, c), C: c},
+			first:  first,
+		})
+	}
+
+	base := floor(cdls[0].C)
+	for i, cdl := range cdls {
+		if len(spans) == 0 {
+			if hi := floor(cdl.C); hi >= base+size {
+				add(base, hi, 0)
+			} else if lo := ceil(cdl.C); lo <= base-size {
+				add(base, lo, 0)
+			}
+		} else {
+			col := &spans[len(spans)-1]
+			if col.C > col.O {
+				// Xs
+				if hi := floor(cdl.C); hi > col.C {
+					col.C, col.H = hi, hi
+				} else if lo := ceil(cdl.C); lo <= col.C-size*float64(reversal+1) {
+					col.last = i - 1
+					add(col.C-size, lo, i)
+				}
+			} else {
+				// Os
+				if lo := ceil(cdl.C); lo < col.C {
+					col.C, col.L = lo, lo
+				} else if hi := floor(cdl.C); hi >= col.C+size*float64(reversal+1) {
+					col.last = i - 1
+					add(col.C+size, hi, i)
+				}
+			}
+		}
+	}
+	if len(spans) > 0 {
+		spans[len(spans)-1].last = len(cdls) - 1
+	}
+
+	return spanCandles(cdls, spans)
+}
@@ -0,0 +1,141 @@
+package tachart
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+// PriceStyle is how prices are drawn on the candlestick grid.
+type PriceStyle byte
+
+const (
+	// filled candlesticks
+	PriceCandlestick PriceStyle = iota
+	// candlesticks with hollow up candles and filled down candles
+	PriceHollowCandle
+	// OHLC bars, with open ticked on the left and close ticked on the right
+	PriceOHLCBar
+	// line of close prices
+	PriceLine
+	// line of close prices with the area below filled
+	PriceArea
+)
+
+const (
+	// name of the price series, which the tooltip formatter looks for
+	priceSeriesName = "kline"
+
+	// NOTE: js funcs are joined into a single line, statements must end with ';' and no '//' comments.
+	// Values are [x, open, close, low, high], x is the slot on x axis, empty slots have no values.
+	ohlcBarRenderItemFuncTpl = `
+		function(params, api) {
+			var x = api.value(0);
+			if (isNaN(x)) {
+				return;
+			}
+			var o = api.coord([x, api.value(1)]);
+			var c = api.coord([x, api.value(2)]);
+			var l = api.coord([x, api.value(3)]);
+			var h = api.coord([x, api.value(4)]);
+			var w = api.size([1, 0])[0] * 0.3;
+			var style = api.style({
+				stroke: api.value(1) > api.value(2) ? '__DOWN_COLOR__' : '__UP_COLOR__',
+				lineWidth: 1.5,
+				opacity: __OPACITY__
+			});
+			return {
+				type: 'group',
+				children: [
+					{type: 'line', shape: {x1: l[0], y1: l[1], x2: h[0], y2: h[1]}, style: style},
+					{type: 'line', shape: {x1: o[0] - w, y1: o[1], x2: o[0], y2: o[1]}, style: style},
+					{type: 'line', shape: {x1: c[0], y1: c[1], x2: c[0] + w, y2: c[1]}, style: style}
+				]
+			};
+		}`
+)
+
+// hasOHLC tells whether the price series carries OHLC values, otherwise only close prices are drawn.
+func (s PriceStyle) hasOHLC() bool {
+	return s != PriceLine && s != PriceArea
+}
+
+// genPriceChart creates the candlestick grid chart with the price series drawn in style.
+// slots are the x axis slots of cdls.
+func genPriceChart(style PriceStyle, cdls []Candle, slots []int, xAxis []string) *charts.Kline {
+	chart := charts.NewKLine().SetXAxis(xAxis)
+
+	switch style {
+	case PriceOHLCBar:
+		items := []opts.CustomData{}
+		for i, cdl := range cdls {
+			items = append(items, opts.CustomData{Value: []float64{float64(slots[i]), cdl.O, cdl.C, cdl.L, cdl.H}})
+		}
+		renderItem := strings.Replace(ohlcBarRenderItemFuncTpl, "__UP_COLOR__", colorUpBar, -1)
+		renderItem = strings.Replace(renderItem, "__DOWN_COLOR__", colorDownBar, -1)
+		renderItem = strings.Replace(renderItem, "__OPACITY__", fmt.Sprintf("%v", opacityHeavy), -1)
+		chart.Overlap(charts.NewCustom().
+			SetXAxis(xAxis).
+			AddSeries(priceSeriesName, items,
+				charts.WithCustomChartOpts(opts.CustomChart{
+					RenderItem: opts.FuncOpts(renderItem),
+				}),
+				charts.WithEncodeOpts(opts.Encode{
+					X: 0,
+					Y: []int{1, 2, 3, 4},
+				}),
+				charts.WithItemStyleOpts(opts.ItemStyle{
+					Color: colorPriceLine,
+				})))
+	case PriceLine, PriceArea:
+		closes := []float64{}
+		for _, cdl := range cdls {
+			closes = append(closes, cdl.C)
+		}
+		seriesOpts := []charts.SeriesOpts{
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol: "none",
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color: colorPriceLine,
+				Width: 1.5,
+			}),
+			charts.WithItemStyleOpts(opts.ItemStyle{
+				Color: colorPriceLine,
+			}),
+		}
+		if style == PriceArea {
+			seriesOpts = append(seriesOpts, charts.WithAreaStyleOpts(opts.AreaStyle{
+				Color:   colorPriceLine,
+				Origin:  "start",
+				Opacity: opacityLight,
+			}))
+		}
+		chart.Overlap(charts.NewLine().SetXAxis(xAxis).AddSeries(priceSeriesName, lineData(closes), seriesOpts...))
+	default:
+		items := []opts.KlineData{}
+		for _, cdl := range cdls {
+			// open,close,low,high
+			items = append(items, opts.KlineData{Value: []float64{cdl.O, cdl.C, cdl.L, cdl.H}})
+		}
+		itemStyle := opts.ItemStyle{
+			Color:        colorUpBar,
+			Color0:       colorDownBar,
+			BorderColor:  colorUpBar,
+			BorderColor0: colorDownBar,
+			Opacity:      opacityHeavy,
+		}
+		if style == PriceHollowCandle {
+			itemStyle.Color = "transparent"
+		}
+		chart.AddSeries(priceSeriesName, items,
+			charts.WithKlineChartOpts(opts.KlineChart{
+				BarWidth: "60%",
+			}),
+			charts.WithItemStyleOpts(itemStyle),
+		)
+	}
+	return chart
+}
@@ -0,0 +1,43 @@
+package tachart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceStyle(t *testing.T) {
+	cases := []struct {
+		style      PriceStyle
+		seriesType string
+		ohlcMap    bool
+	}{
+		{PriceCandlestick, "candlestick", false},
+		{PriceHollowCandle, "candlestick", false},
+		{PriceOHLCBar, "custom", false},
+		{PriceLine, "line", true},
+		{PriceArea, "line", true},
+	}
+	for _, tc := range cases {
+		chart, err := New(*testConfig().SetPriceStyle(tc.style)).genChart(testCdls, testEvents)
+		assert.NoError(t, err)
+
+		price := chart.MultiSeries[0]
+		assert.Equal(t, priceSeriesName, price.Name)
+		assert.Equal(t, tc.seriesType, price.Type)
+		assert.Equal(t, len(testCdls), sliceLen(price.Data))
+		// real OHLC is passed to the tooltip when the price series has close prices only
+		cdl := testCdls[0]
+		realOHLC := toJson([]float64{cdl.O, cdl.C, cdl.L, cdl.H})
+		assert.Equal(t, tc.ohlcMap, strings.Contains(string(chart.Tooltip.Formatter), strings.TrimSpace(realOHLC)))
+
+		names := map[string]bool{}
+		for _, s := range chart.MultiSeries {
+			names[s.Name] = true
+		}
+		assert.True(t, names["SMA(5)"])
+		assert.True(t, names["events"])
+		assert.True(t, names["Vol"])
+	}
+}
@@ -0,0 +1,303 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+// ProfileRange is the range of candles the volume profile is built from.
+type ProfileRange byte
+
+const (
+	// all candles
+	ProfileAll ProfileRange = iota
+	// candles in the visible dataZoom window, the profile is rebuilt as the window changes
+	ProfileVisible
+)
+
+const (
+	profileSeriesName = "Volume Profile"
+	// key of profile candles in options pushed by Stream
+	profileCandlesKey = "profileCandles"
+	// portion of the candlestick grid width taken by the longest bar of the profile
+	profileWidth = 0.25
+	// value area of the profile
+	defaultValueArea = 0.7
+
+	colorProfile          = "#808080"
+	colorProfileValueArea = "#3A6EA5"
+	colorProfilePOC       = "#FFA500"
+
+	// NOTE: js funcs are joined into a single line, statements must end with ';' and no '//' comments.
+	// Values are [low, high, volume, flag] of price buckets, flag is 2 for POC, 1 for value area and 0 otherwise.
+	profileRenderItemFuncTpl = `
+		function(params, api) {
+			var bottom = api.coord([0, api.value(0)]);
+			var top = api.coord([api.value(2), api.value(1)]);
+			var flag = api.value(3);
+			return {
+				type: 'rect',
+				shape: {x: top[0], y: top[1], width: bottom[0] - top[0], height: Math.max(bottom[1] - top[1] - 1, 1)},
+				style: api.style({
+					fill: flag === 2 ? '__POC_COLOR__' : (flag === 1 ? '__VALUE_AREA_COLOR__' : '__COLOR__'),
+					opacity: __OPACITY__
+				})
+			};
+		}`
+	profileMaxFunc = `
+		function(value) {
+			return value.max / __WIDTH__;
+		}`
+	// rebuilds the profile from candles [slot, low, high, volume] in the visible window, mirrors volumeProfile.
+	// Candles are replaced by those pushed along with live updates, see Stream.
+	profileUpdateFuncTpl = `
+		(function() {
+			var chart = %MY_ECHARTS%;
+			var cdls = JSON.parse('__PROFILE_CANDLES__');
+			var rows = __ROWS__;
+			var valueArea = __VALUE_AREA__;
+			var build = function(from, to) {
+				var sel = cdls.filter(c => c[0] >= from && c[0] <= to);
+				if (sel.length === 0) {
+					return {data: [], lines: []};
+				}
+				var lo = Math.min.apply(null, sel.map(c => c[1]));
+				var hi = Math.max.apply(null, sel.map(c => c[2]));
+				var size = (hi - lo) / rows;
+				var vols = [];
+				for (var b = 0; b < rows; b++) {
+					vols.push(0);
+				}
+				var bucket = (p) => size > 0 ? Math.min(rows - 1, Math.floor((p - lo) / size)) : 0;
+				sel.forEach(c => {
+					if (!(c[2] > c[1]) || !(size > 0)) {
+						vols[bucket(c[2])] += c[3];
+						return;
+					}
+					for (var b = bucket(c[1]); b <= bucket(c[2]); b++) {
+						var overlap = Math.min(c[2], lo + (b+1)*size) - Math.max(c[1], lo + b*size);
+						vols[b] += c[3] * Math.max(overlap, 0) / (c[2] - c[1]);
+					}
+				});
+				var poc = 0;
+				var total = 0;
+				for (var b = 0; b < rows; b++) {
+					total += vols[b];
+					if (vols[b] > vols[poc]) {
+						poc = b;
+					}
+				}
+				var vaLow = poc;
+				var vaHigh = poc;
+				var sum = vols[poc];
+				while (sum < total * valueArea && (vaLow > 0 || vaHigh < rows - 1)) {
+					var up = vaHigh < rows - 1 ? vols[vaHigh+1] : -1;
+					var down = vaLow > 0 ? vols[vaLow-1] : -1;
+					if (up >= down) {
+						vaHigh++;
+						sum += up;
+					} else {
+						vaLow--;
+						sum += down;
+					}
+				}
+				var data = [];
+				for (var b = 0; b < rows; b++) {
+					var flag = b === poc ? 2 : (b >= vaLow && b <= vaHigh ? 1 : 0);
+					data.push({value: [lo + b*size, lo + (b+1)*size, vols[b], flag]});
+				}
+				var lines = [
+					{name: 'POC', yAxis: lo + (poc+0.5)*size},
+					{name: 'VAH', yAxis: lo + (vaHigh+1)*size},
+					{name: 'VAL', yAxis: lo + vaLow*size}
+				];
+				return {data: data, lines: lines};
+			};
+			var rebuild = function() {
+				var opt = chart.getOption();
+				var n = opt.xAxis[0].data.length;
+				var dz = opt.dataZoom[0];
+				var p = build(Math.floor(dz.start / 100 * (n-1)), Math.ceil(dz.end / 100 * (n-1)));
+				chart.setOption({series: [{name: '__SERIES_NAME__', data: p.data, markLine: {data: p.lines}}]});
+			};
+			chart.on('datazoom', rebuild);
+			chart.getDom().addEventListener('__UPDATE_EVENT__', function(e) {
+				if (e.detail.__PROFILE_CANDLES_KEY__) {
+					cdls = e.detail.__PROFILE_CANDLES_KEY__;
+				}
+				rebuild();
+			});
+		})();`
+)
+
+// volumeProfile is the volume traded per price bucket of candles. Volume of a candle is spread
+// evenly over its low to high range.
+type volumeProfile struct {
+	low    float64   // low of the first bucket
+	size   float64   // price range of a bucket
+	vols   []float64 // volume of each bucket
+	poc    int       // bucket of the point of control, where most volume is traded
+	vaLow  int       // first bucket of value area
+	vaHigh int       // last bucket of value area
+}
+
+// newVolumeProfile builds a volume profile of rows buckets. The value area is the range of buckets
+// around the POC with valueArea (e.g. 0.7) of the total volume, extended towards the side of larger volume.
+func newVolumeProfile(cdls []Candle, rows int, valueArea float64) volumeProfile {
+	p := volumeProfile{
+		vols: make([]float64, rows),
+	}
+	if len(cdls) == 0 || rows <= 0 {
+		return p
+	}
+
+	lo, hi := cdls[0].L, cdls[0].H
+	for _, cdl := range cdls {
+		lo = math.Min(lo, cdl.L)
+		hi = math.Max(hi, cdl.H)
+	}
+	p.low = lo
+	p.size = (hi - lo) / float64(rows)
+	bucket := func(price float64) int {
+		if !(p.size > 0) {
+			return 0
+		}
+		return int(math.Min(float64(rows-1), math.Floor((price-lo)/p.size)))
+	}
+	for _, cdl := range cdls {
+		if !(cdl.H > cdl.L) || !(p.size > 0) {
+			p.vols[bucket(cdl.H)] += cdl.V
+			continue
+		}
+		for b := bucket(cdl.L); b <= bucket(cdl.H); b++ {
+			overlap := math.Min(cdl.H, lo+float64(b+1)*p.size) - math.Max(cdl.L, lo+float64(b)*p.size)
+			p.vols[b] += cdl.V * math.Max(overlap, 0) / (cdl.H - cdl.L)
+		}
+	}
+
+	total := 0.0
+	for b, v := range p.vols {
+		total += v
+		if v > p.vols[p.poc] {
+			p.poc = b
+		}
+	}
+	p.vaLow, p.vaHigh = p.poc, p.poc
+	sum := p.vols[p.poc]
+	for sum < total*valueArea && (p.vaLow > 0 || p.vaHigh < rows-1) {
+		up, down := -1.0, -1.0
+		if p.vaHigh < rows-1 {
+			up = p.vols[p.vaHigh+1]
+		}
+		if p.vaLow > 0 {
+			down = p.vols[p.vaLow-1]
+		}
+		if up >= down {
+			p.vaHigh++
+			sum += up
+		} else {
+			p.vaLow--
+			sum += down
+		}
+	}
+	return p
+}
+
+// price returns the price at bucket b plus fraction f of the bucket.
+func (p volumeProfile) price(b int, f float64) float64 {
+	return p.low + (float64(b)+f)*p.size
+}
+
+// genVolumeProfileChart draws the profile as a horizontal histogram on the right side of the
+// candlestick grid, with bar lengths on x axis xAxisIndex, marking the POC and the value area.
+func genVolumeProfileChart(p volumeProfile, xAxisIndex int) *charts.Custom {
+	items := []opts.CustomData{}
+	for b, v := range p.vols {
+		flag := 0
+		if b == p.poc {
+			flag = 2
+		} else if b >= p.vaLow && b <= p.vaHigh {
+			flag = 1
+		}
+		items = append(items, opts.CustomData{Value: []float64{p.price(b, 0), p.price(b, 1), v, float64(flag)}})
+	}
+
+	renderItem := strings.Replace(profileRenderItemFuncTpl, "__POC_COLOR__", colorProfilePOC, -1)
+	renderItem = strings.Replace(renderItem, "__VALUE_AREA_COLOR__", colorProfileValueArea, -1)
+	renderItem = strings.Replace(renderItem, "__COLOR__", colorProfile, -1)
+	renderItem = strings.Replace(renderItem, "__OPACITY__", fmt.Sprintf("%v", opacityLight), -1)
+
+	seriesOpts := []charts.SeriesOpts{
+		charts.WithCustomChartOpts(opts.CustomChart{
+			XAxisIndex: xAxisIndex,
+			RenderItem: opts.FuncOpts(renderItem),
+		}),
+		charts.WithEncodeOpts(opts.Encode{
+			X: 2,
+			Y: []int{0, 1},
+		}),
+		charts.WithSeriesTooltipOpts(opts.Tooltip{Show: opts.Bool(false)}),
+		charts.WithSeriesZ(1),
+		charts.WithMarkLineStyleOpts(opts.MarkLineStyle{
+			Symbol: []string{"none", "none"},
+			Label: &opts.Label{
+				Show:      opts.Bool(true),
+				Position:  "start",
+				Formatter: "{b}",
+			},
+			LineStyle: &opts.LineStyle{
+				Color:   colorProfilePOC,
+				Type:    "dashed",
+				Opacity: opacityMed,
+			},
+		}),
+	}
+	if len(items) > 0 && p.size > 0 {
+		seriesOpts = append(seriesOpts, charts.WithMarkLineNameYAxisItemOpts(
+			opts.MarkLineNameYAxisItem{Name: "POC", YAxis: p.price(p.poc, 0.5)},
+			opts.MarkLineNameYAxisItem{Name: "VAH", YAxis: p.price(p.vaHigh, 1)},
+			opts.MarkLineNameYAxisItem{Name: "VAL", YAxis: p.price(p.vaLow, 0)},
+		))
+	}
+
+	return charts.NewCustom().AddSeries(profileSeriesName, items, seriesOpts...)
+}
+
+// profileXAxis is the x axis of profile bars on the candlestick grid, growing from the right.
+func profileXAxis() opts.XAxis {
+	return opts.XAxis{
+		Show:      opts.Bool(false),
+		Type:      "value",
+		GridIndex: 0,
+		Inverse:   opts.Bool(true),
+		Min:       0,
+		Max:       opts.FuncOpts(strings.Replace(profileMaxFunc, "__WIDTH__", fmt.Sprintf("%v", profileWidth), -1)),
+		AxisPointer: &opts.AxisPointer{
+			Show: opts.Bool(false),
+		},
+	}
+}
+
+// profileUpdateFunc returns the js func rebuilding the profile of cdls as the dataZoom window changes,
+// slots are the x axis slots of cdls.
+func profileUpdateFunc(cdls []Candle, slots []int, rows int, valueArea float64) string {
+	js := strings.Replace(profileUpdateFuncTpl, "__PROFILE_CANDLES__", strings.TrimSpace(toJson(profileCandles(cdls, slots))), -1)
+	js = strings.Replace(js, "__ROWS__", fmt.Sprintf("%v", rows), -1)
+	js = strings.Replace(js, "__VALUE_AREA__", fmt.Sprintf("%v", valueArea), -1)
+	js = strings.Replace(js, "__UPDATE_EVENT__", liveUpdateEvent, -1)
+	js = strings.Replace(js, "__PROFILE_CANDLES_KEY__", profileCandlesKey, -1)
+	return strings.Replace(js, "__SERIES_NAME__", profileSeriesName, -1)
+}
+
+// profileCandles returns [slot, low, high, volume] of cdls, which the chart page rebuilds the profile from.
+func profileCandles(cdls []Candle, slots []int) [][]float64 {
+	data := [][]float64{}
+	for i, cdl := range cdls {
+		data = append(data, []float64{float64(slots[i]), cdl.L, cdl.H, cdl.V})
+	}
+	return data
+}
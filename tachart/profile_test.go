@@ -0,0 +1,49 @@
+package tachart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeProfile(t *testing.T) {
+	cdls := []Candle{
+		{L: 0, H: 10, V: 100},
+		{L: 4, H: 6, V: 100},
+	}
+	p := newVolumeProfile(cdls, 5, 0.7)
+	assert.Equal(t, 2.0, p.size)
+	assert.InDeltaSlice(t, []float64{20, 20, 120, 20, 20}, p.vols, 1e-9)
+	assert.Equal(t, 2, p.poc)
+	assert.Equal(t, 2, p.vaLow)
+	assert.Equal(t, 3, p.vaHigh)
+	assert.Equal(t, 5.0, p.price(p.poc, 0.5))
+
+	// all at the same price
+	p = newVolumeProfile([]Candle{{L: 1, H: 1, V: 10}}, 3, 0.7)
+	assert.Equal(t, []float64{10, 0, 0}, p.vols)
+	// values out of (0, 1] are ignored
+	for _, pct := range []float64{0, -0.5, 1.5} {
+		assert.Equal(t, defaultValueArea, NewConfig().SetValueArea(pct).valueArea)
+	}
+	assert.Equal(t, 1.0, NewConfig().SetValueArea(1).valueArea)
+}
+
+func TestVolumeProfileChart(t *testing.T) {
+	cfg := testConfig().SetVolumeProfile(12, ProfileVisible)
+	chart, err := New(*cfg).genChart(testCdls, testEvents)
+	assert.NoError(t, err)
+
+	profile := chart.MultiSeries[len(chart.MultiSeries)-1]
+	assert.Equal(t, profileSeriesName, profile.Name)
+	assert.Equal(t, 12, sliceLen(profile.Data))
+	assert.Equal(t, len(chart.XAxisList)-1, profile.XAxisIndex)
+	assert.Equal(t, "value", chart.XAxisList[len(chart.XAxisList)-1].Type)
+	assert.Len(t, chart.JSFunctions.Fns, 1)
+
+	chart, err = New(*testConfig()).genChart(testCdls, testEvents)
+	assert.NoError(t, err)
+	for _, s := range chart.MultiSeries {
+		assert.NotEqual(t, profileSeriesName, s.Name)
+	}
+}
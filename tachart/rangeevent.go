@@ -0,0 +1,107 @@
+package tachart
+
+import (
+	"time"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+const (
+	colorRangeEvent = "#808080"
+)
+
+// RangeEvent is a span of candles, e.g. an earnings window, a drawdown period or a market-closed block,
+// shaded as a vertical band on candlestick chart. Start and end are matched to candles like events,
+// by Label or T.
+type RangeEvent struct {
+	StartLabel  string    // x-axis label of the first candle
+	StartT      time.Time // start time, optional. If set, span starts from the candle whose period contains StartT
+	EndLabel    string    // x-axis label of the last candle
+	EndT        time.Time // end time, optional. If set, span ends at the candle whose period contains EndT
+	Color       string    // band color, gray if empty
+	Description string    // any user-defined description wants to appear on tooltip within the span
+	AllPanes    bool      // shade indicator and volume charts as well
+}
+
+// slots returns the first and last slot of the span.
+func (r RangeEvent) slots(axis *candleAxis) (int, int, error) {
+	start, err := axis.eventSlot(Event{Label: r.StartLabel, T: r.StartT})
+	if err != nil {
+		return 0, 0, ErrUnknownRangeEventCandle
+	}
+	end, err := axis.eventSlot(Event{Label: r.EndLabel, T: r.EndT})
+	if err != nil {
+		return 0, 0, ErrUnknownRangeEventCandle
+	}
+	if end < start {
+		start, end = end, start
+	}
+	return start, end, nil
+}
+
+// genRangeEventChart shades spans on the candlestick chart, and on grids 2 to 2+numPanes-1 for
+// spans on all panes. Spans are returned as [first slot, last slot, description] for tooltip.
+// Spans matching no candle are skipped if skipUnmatched, otherwise it's an error.
+func genRangeEventChart(rangeEvents []RangeEvent, axis *candleAxis, numPanes int, skipUnmatched bool) (charts.Overlaper, [][]interface{}, error) {
+	// mark areas by grid index
+	areas := map[int][][]opts.MarkAreaData{}
+	spans := [][]interface{}{}
+	for _, r := range rangeEvents {
+		start, end, err := r.slots(axis)
+		if err != nil {
+			if skipUnmatched {
+				continue
+			}
+			return nil, nil, err
+		}
+		spans = append(spans, []interface{}{start, end, r.Description})
+
+		color := r.Color
+		if color == "" {
+			color = colorRangeEvent
+		}
+		area := []opts.MarkAreaData{
+			{
+				XAxis: start,
+				MarkAreaStyle: opts.MarkAreaStyle{
+					Label: &opts.Label{
+						Show: opts.Bool(false),
+					},
+					ItemStyle: &opts.ItemStyle{
+						Color:   color,
+						Opacity: opacityFill,
+					},
+				},
+			},
+			{
+				XAxis: end,
+			},
+		}
+		areas[0] = append(areas[0], area)
+		if r.AllPanes {
+			for i := 0; i < numPanes; i++ {
+				areas[i+2] = append(areas[i+2], area)
+			}
+		}
+	}
+
+	gridIndexes := []int{0}
+	for i := 0; i < numPanes; i++ {
+		gridIndexes = append(gridIndexes, i+2)
+	}
+	chart := charts.NewScatter()
+	for _, gridIndex := range gridIndexes {
+		if len(areas[gridIndex]) == 0 {
+			continue
+		}
+		chart.AddSeries("range-events", []opts.ScatterData{},
+			charts.WithScatterChartOpts(opts.ScatterChart{
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithMarkAreaData(areas[gridIndex]...),
+			charts.WithSeriesZ(1))
+	}
+	return chart, spans, nil
+}
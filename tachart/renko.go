@@ -0,0 +1,129 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iamjinlei/go-tart"
+)
+
+// BoxSize returns the price range of a Renko brick or a Point-and-Figure box for the given candles.
+type BoxSize func(cdls []Candle) float64
+
+// FixedBoxSize returns a BoxSize of fixed price range v.
+func FixedBoxSize(v float64) BoxSize {
+	return func(_ []Candle) float64 {
+		return v
+	}
+}
+
+// ATRBoxSize returns a BoxSize of the latest ATR(n) of candles.
+func ATRBoxSize(n int) BoxSize {
+	return func(cdls []Candle) float64 {
+		if len(cdls) <= n {
+			return 0
+		}
+		highs := make([]float64, len(cdls))
+		lows := make([]float64, len(cdls))
+		closes := make([]float64, len(cdls))
+		for i, cdl := range cdls {
+			highs[i] = cdl.H
+			lows[i] = cdl.L
+			closes[i] = cdl.C
+		}
+		vals := tart.AtrArr(highs, lows, closes, int64(n))
+		return vals[len(vals)-1]
+	}
+}
+
+// Renko builds Renko bricks from closes of candles. A new brick is added once close moves
+// a box above the top or a box below the bottom of the last brick, so reversals need a move of
+// two boxes. Closes not completing a brick after the last one are left out.
+//
+// Bricks are returned as candles to be charted like any other candles, i.e. with the same
+// config, overlays and indicators. Brick time is not set, instead brick labels show the range
+// of candles spanned (e.g. "2018/1/24 ~ 2018/1/29"), and bricks hold the total volume of them.
+// Events of the chart are to be placed on brick labels.
+func Renko(cdls []Candle, box BoxSize) []Candle {
+	size := box(cdls)
+	if len(cdls) == 0 || !(size > 0) {
+		return nil
+	}
+
+	spans := []candleSpan{}
+	top := cdls[0].C
+	bottom := top
+	first := 0
+	for i, cdl := range cdls {
+		for {
+			var o, c float64
+			if cdl.C >= top+size {
+				o, c = top, top+size
+			} else if cdl.C <= bottom-size {
+				o, c = bottom, bottom-size
+			} else {
+				break
+			}
+			top, bottom = math.Max(o, c), math.Min(o, c)
+			spans = append(spans, candleSpan{
+				Candle: Candle{O: o, H: top, L: bottom, C: c},
+				first:  first,
+				last:   i,
+			})
+			first = i
+		}
+		if len(spans) > 0 && spans[len(spans)-1].last == i {
+			first = i + 1
+		}
+	}
+
+	return spanCandles(cdls, spans)
+}
+
+// candleSpan is a candle built from candles first to last.
+type candleSpan struct {
+	Candle
+	first int
+	last  int
+}
+
+// spanCandles returns candles of spans, labeled with the candles spanned. Volume of each candle
+// goes to the first span covering it.
+func spanCandles(cdls []Candle, spans []candleSpan) []Candle {
+	layout := ""
+	label := func(cdl Candle) string {
+		if cdl.Label != "" || cdl.T.IsZero() {
+			return cdl.Label
+		}
+		if layout == "" {
+			times := []time.Time{}
+			for _, cdl := range cdls {
+				times = append(times, cdl.T)
+			}
+			layout = defaultTimeFormat(Config{}, times)
+		}
+		return cdl.T.Format(layout)
+	}
+
+	ret := []Candle{}
+	seen := map[string]int{}
+	next := 0
+	for _, s := range spans {
+		cdl := s.Candle
+		cdl.Label = label(cdls[s.first])
+		if s.last != s.first {
+			cdl.Label += " ~ " + label(cdls[s.last])
+		}
+		// several bricks can be built from the same candles
+		seen[cdl.Label]++
+		if n := seen[cdl.Label]; n > 1 {
+			cdl.Label += fmt.Sprintf(" #%v", n)
+		}
+		for ; next <= s.last; next++ {
+			cdl.V += cdls[next].V
+		}
+		ret = append(ret, cdl)
+	}
+	return ret
+}
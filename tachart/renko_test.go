@@ -0,0 +1,61 @@
+package tachart
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func closeCandles(closes ...float64) []Candle {
+	cdls := []Candle{}
+	for i, c := range closes {
+		cdls = append(cdls, Candle{Label: fmt.Sprintf("c%v", i), O: c, H: c, L: c, C: c, V: 1})
+	}
+	return cdls
+}
+
+func TestRenko(t *testing.T) {
+	bricks := Renko(closeCandles(100, 105, 111, 125, 118, 108), FixedBoxSize(5))
+
+	labels := []string{}
+	ocs := [][]float64{}
+	vols := []float64{}
+	for _, b := range bricks {
+		labels = append(labels, b.Label)
+		ocs = append(ocs, []float64{b.O, b.C})
+		vols = append(vols, b.V)
+	}
+	assert.Equal(t, []string{"c0 ~ c1", "c2", "c3", "c3 #2", "c3 #3", "c4 ~ c5", "c5"}, labels)
+	assert.Equal(t, [][]float64{{100, 105}, {105, 110}, {110, 115}, {115, 120}, {120, 125}, {120, 115}, {115, 110}}, ocs)
+	assert.Equal(t, []float64{2, 1, 1, 0, 0, 2, 0}, vols)
+	assert.Equal(t, 125.0, bricks[4].H)
+	assert.Equal(t, 115.0, bricks[5].L)
+
+	assert.Nil(t, Renko(closeCandles(100, 105), ATRBoxSize(14)))
+
+	var buf bytes.Buffer
+	err := New(*testConfig()).Render(&buf, bricks, []Event{{Type: Long, Label: "c3 #2"}})
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "c4 ~ c5")
+}
+
+func TestPointFigure(t *testing.T) {
+	cdls := hourlyCandles("2021-06-04 09:00", "2021-06-04 10:00", "2021-06-04 11:00", "2021-06-04 12:00",
+		"2021-06-04 13:00", "2021-06-04 14:00", "2021-06-04 15:00", "2021-06-04 16:00")
+	for i, c := range []float64{10, 11.5, 13.2, 12.4, 10.6, 8.7, 12.0, 13.5} {
+		cdls[i].C = c
+	}
+
+	cols := PointFigure(cdls, FixedBoxSize(1), 3)
+	assert.Len(t, cols, 3)
+	assert.Equal(t, "2021/06/04 09:00 ~ 2021/06/04 13:00", cols[0].Label)
+	assert.Equal(t, []float64{10, 13}, []float64{cols[0].O, cols[0].C})
+	assert.Equal(t, "2021/06/04 14:00 ~ 2021/06/04 15:00", cols[1].Label)
+	assert.Equal(t, []float64{12, 9}, []float64{cols[1].O, cols[1].C})
+	assert.Equal(t, "2021/06/04 16:00", cols[2].Label)
+	assert.Equal(t, []float64{10, 13}, []float64{cols[2].O, cols[2].C})
+	assert.Equal(t, 500.0, cols[0].V)
+	assert.True(t, cols[1].T.IsZero())
+}
@@ -2,7 +2,6 @@ package tachart
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/iamjinlei/go-tart"
 
@@ -27,27 +26,27 @@ func NewRSI(n int, oversold, overbought float64) Indicator {
 	}
 }
 
-func (r rsi) name() string {
+func (r rsi) Name() string {
 	return r.nm
 }
 
-func (r rsi) yAxisLabel() string {
-	return strings.Replace(yLabelFormatterFuncTpl, "__DECIMAL_PLACES__", "0", -1)
+func (r rsi) YAxisLabel() string {
+	return YLabelFormatterFunc(0)
 }
 
-func (r rsi) yAxisMin() string {
-	return `function(value) { return 0 }`
+func (r rsi) YAxisMin() string {
+	return FixedValueFunc(0)
 }
 
-func (r rsi) yAxisMax() string {
-	return `function(value) { return 100 }`
+func (r rsi) YAxisMax() string {
+	return FixedValueFunc(100)
 }
 
-func (r rsi) getNumColors() int {
+func (r rsi) GetNumColors() int {
 	return 1
 }
 
-func (r *rsi) getTitleOpts(top, left int, colorIndex int) []opts.Title {
+func (r *rsi) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
 	r.ci = colorIndex
 	return []opts.Title{
 		{
@@ -62,17 +61,13 @@ func (r *rsi) getTitleOpts(top, left int, colorIndex int) []opts.Title {
 	}
 }
 
-func (r rsi) genChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+func (r rsi) GenChart(_, _, _, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
 	vals := tart.RsiArr(closes, r.n)
-
-	lineItems := []opts.LineData{}
-	for _, v := range vals {
-		lineItems = append(lineItems, opts.LineData{Value: v})
-	}
+	gapWarmUp(int(r.n), vals)
 
 	return charts.NewLine().
 		SetXAxis(xAxis).
-		AddSeries(r.nm, lineItems,
+		AddSeries(r.nm, lineData(vals),
 			charts.WithLineChartOpts(opts.LineChart{
 				Symbol:     "none",
 				XAxisIndex: gridIndex,
@@ -0,0 +1,152 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type sar struct {
+	nm    string
+	accel float64
+	max   float64
+	ci    int
+}
+
+// NewSAR creates Parabolic SAR overlay, e.g. NewSAR(0.02, 0.2). Dots are drawn below candles
+// in up trend and above candles in down trend.
+func NewSAR(accel, max float64) Indicator {
+	return &sar{
+		nm:    fmt.Sprintf("SAR(%v,%v)", accel, max),
+		accel: accel,
+		max:   max,
+	}
+}
+
+func (s sar) Name() string {
+	return s.nm
+}
+
+func (s sar) YAxisLabel() string {
+	return ""
+}
+
+func (s sar) YAxisMin() string {
+	return ""
+}
+
+func (s sar) YAxisMax() string {
+	return ""
+}
+
+func (s sar) GetNumColors() int {
+	return 1
+}
+
+func (s *sar) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	s.ci = colorIndex
+	return []opts.Title{
+		LegendTitle(s.nm, top, left, s.ci),
+	}
+}
+
+func (s sar) GenChart(_, highs, lows, _, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	vals, long := parabolicSar(highs, lows, s.accel, s.max)
+
+	items := []opts.ScatterData{}
+	for i, v := range vals {
+		if math.IsNaN(v) {
+			items = append(items, opts.ScatterData{Value: "-"})
+			continue
+		}
+		color := colorDownBar
+		if long[i] {
+			color = colorUpBar
+		}
+		items = append(items, opts.ScatterData{
+			Value: v,
+			ItemStyle: &opts.ItemStyle{
+				Color:   color,
+				Opacity: opacityHeavy,
+			},
+		})
+	}
+
+	return charts.NewScatter().
+		SetXAxis(xAxis).
+		AddSeries(s.nm, items,
+			charts.WithScatterChartOpts(opts.ScatterChart{
+				Symbol:     "circle",
+				SymbolSize: 4,
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithItemStyleOpts(opts.ItemStyle{
+				Color: Color(s.ci),
+			}),
+		)
+}
+
+// parabolicSar computes Wilder's Parabolic SAR and the trend of each period, in the way of TA-Lib.
+// The first value is NaN.
+func parabolicSar(highs, lows []float64, accel, max float64) ([]float64, []bool) {
+	n := len(highs)
+	vals := make([]float64, n)
+	long := make([]bool, n)
+	if n == 0 {
+		return vals, long
+	}
+	vals[0] = math.NaN()
+	if n < 2 {
+		return vals, long
+	}
+
+	// initial trend by directional movement of the first 2 periods
+	isLong := !(lows[0]-lows[1] > 0 && lows[0]-lows[1] > highs[1]-highs[0])
+	af := accel
+	var sar, ep float64
+	if isLong {
+		sar, ep = lows[0], highs[1]
+	} else {
+		sar, ep = highs[0], lows[1]
+	}
+
+	for i := 1; i < n; i++ {
+		if isLong {
+			if lows[i] <= sar {
+				// reverse to short, starting from the extreme point of the up trend
+				isLong = false
+				sar = math.Max(ep, math.Max(highs[i], highs[i-1]))
+				vals[i], long[i] = sar, false
+				af, ep = accel, lows[i]
+				sar = math.Max(sar+af*(ep-sar), math.Max(highs[i], highs[i-1]))
+				continue
+			}
+			vals[i], long[i] = sar, true
+			if highs[i] > ep {
+				ep = highs[i]
+				af = math.Min(af+accel, max)
+			}
+			sar = math.Min(sar+af*(ep-sar), math.Min(lows[i], lows[i-1]))
+		} else {
+			if highs[i] >= sar {
+				// reverse to long, starting from the extreme point of the down trend
+				isLong = true
+				sar = math.Min(ep, math.Min(lows[i], lows[i-1]))
+				vals[i], long[i] = sar, true
+				af, ep = accel, highs[i]
+				sar = math.Min(sar+af*(ep-sar), math.Min(lows[i], lows[i-1]))
+				continue
+			}
+			vals[i], long[i] = sar, false
+			if lows[i] < ep {
+				ep = lows[i]
+				af = math.Min(af+accel, max)
+			}
+			sar = math.Max(sar+af*(ep-sar), math.Max(highs[i], highs[i-1]))
+		}
+	}
+	return vals, long
+}
@@ -0,0 +1,47 @@
+package tachart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/otetz/go-tachart/opts"
+)
+
+func TestParabolicSar(t *testing.T) {
+	highs := []float64{10, 11, 12, 13, 14, 13, 12, 11, 10}
+	lows := []float64{9, 10, 11, 12, 13, 11, 10, 9, 8}
+	vals, long := parabolicSar(highs, lows, 0.02, 0.2)
+
+	assert.True(t, math.IsNaN(vals[0]))
+	assert.Equal(t, []bool{false, true, true, true, true, true, false, false, false}, long)
+	for i := 1; i < len(vals); i++ {
+		if long[i] {
+			assert.LessOrEqual(t, vals[i], lows[i])
+		} else {
+			assert.GreaterOrEqual(t, vals[i], highs[i])
+		}
+	}
+	// reversal starts from the highest high of the up trend
+	assert.Equal(t, 14.0, vals[6])
+}
+
+func TestSAR(t *testing.T) {
+	c := New(*NewConfig().AddOverlay(NewSAR(0.02, 0.2)))
+	chart, err := c.genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	for _, s := range chart.MultiSeries {
+		if s.Name != "SAR(0.02,0.2)" {
+			continue
+		}
+		assert.Equal(t, "scatter", s.Type)
+		data := s.Data.([]opts.ScatterData)
+		assert.Equal(t, len(testCdls), len(data))
+		assert.Equal(t, "-", data[0].Value)
+		assert.NotNil(t, data[1].ItemStyle)
+		return
+	}
+	t.Fatal("SAR series not found")
+}
@@ -0,0 +1,118 @@
+package tachart
+
+import (
+	"fmt"
+
+	"github.com/iamjinlei/go-tart"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+type stoch struct {
+	nm         string
+	kPeriod    int64
+	kSlow      int64
+	dPeriod    int64
+	oversold   float64
+	overbought float64
+	ci         int
+}
+
+func NewStoch(kPeriod, kSlow, dPeriod int, oversold, overbought float64) Indicator {
+	return &stoch{
+		nm:         fmt.Sprintf("Stoch(%v,%v,%v)", kPeriod, kSlow, dPeriod),
+		kPeriod:    int64(kPeriod),
+		kSlow:      int64(kSlow),
+		dPeriod:    int64(dPeriod),
+		oversold:   oversold,
+		overbought: overbought,
+	}
+}
+
+func (s stoch) Name() string {
+	return s.nm
+}
+
+func (s stoch) YAxisLabel() string {
+	return YLabelFormatterFunc(0)
+}
+
+func (s stoch) YAxisMin() string {
+	return FixedValueFunc(0)
+}
+
+func (s stoch) YAxisMax() string {
+	return FixedValueFunc(100)
+}
+
+func (s stoch) GetNumColors() int {
+	return 2
+}
+
+func (s stoch) Lookback() int {
+	return int(s.kPeriod+s.kSlow+s.dPeriod) - 2
+}
+
+func (s *stoch) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	s.ci = colorIndex
+	return []opts.Title{
+		LegendTitle(s.nm+"-%K", top, left, s.ci),
+		LegendTitle(s.nm+"-%D", top+chartLabelFontHeight, left, s.ci+1),
+	}
+}
+
+func (s stoch) GenChart(_, highs, lows, closes, _ []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	k, d := tart.StochSlowArr(highs, lows, closes, s.kPeriod, tart.SMA, s.kSlow, tart.SMA, s.dPeriod)
+	gapWarmUp(int(s.kPeriod+s.kSlow+s.dPeriod)-3, k, d)
+	kLine := charts.NewLine().
+		SetXAxis(xAxis).
+		AddSeries(s.nm+"-%K", lineData(k),
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color:   Color(s.ci),
+				Opacity: opacityMed,
+			}),
+			charts.WithMarkLineNameYAxisItemOpts(
+				opts.MarkLineNameYAxisItem{
+					Name:  "oversold",
+					YAxis: s.oversold,
+				},
+				opts.MarkLineNameYAxisItem{
+					Name:  "overbought",
+					YAxis: s.overbought,
+				},
+			),
+			charts.WithMarkLineStyleOpts(
+				opts.MarkLineStyle{
+					Symbol: []string{"none", "none"},
+					LineStyle: &opts.LineStyle{
+						Color:   colorDownBar,
+						Opacity: opacityMed,
+					},
+				},
+			),
+		)
+
+	dLine := charts.NewLine().
+		SetXAxis(xAxis).
+		AddSeries(s.nm+"-%D", lineData(d),
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color:   Color(s.ci + 1),
+				Opacity: opacityMed,
+			}),
+		)
+
+	kLine.Overlap(dLine)
+
+	return kLine
+}
@@ -0,0 +1,52 @@
+package tachart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoch(t *testing.T) {
+	c := New(*NewConfig().AddIndicator(NewStoch(5, 3, 3, 20, 80)))
+	chart, err := c.genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	// fast %K of the last 5 candles, slowed by SMA(3) into %K, and %D is SMA(3) of %K
+	fastK := make([]float64, len(testCdls))
+	for i := 4; i < len(testCdls); i++ {
+		hh, ll := testCdls[i].H, testCdls[i].L
+		for _, cdl := range testCdls[i-4 : i] {
+			hh = math.Max(hh, cdl.H)
+			ll = math.Min(ll, cdl.L)
+		}
+		fastK[i] = (testCdls[i].C - ll) / (hh - ll) * 100
+	}
+	sma3 := func(vals []float64, i int) float64 {
+		return (vals[i] + vals[i-1] + vals[i-2]) / 3
+	}
+	slowK := make([]float64, len(testCdls))
+	for i := 6; i < len(testCdls); i++ {
+		slowK[i] = sma3(fastK, i)
+	}
+
+	k := seriesValues(t, chart.MultiSeries, "Stoch(5,3,3)-%K")
+	d := seriesValues(t, chart.MultiSeries, "Stoch(5,3,3)-%D")
+	assert.Len(t, k, len(testCdls))
+	// first valid value at kPeriod+kSlow+dPeriod-3
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, "-", k[i])
+		assert.Equal(t, "-", d[i])
+	}
+	for i := 8; i < len(testCdls); i++ {
+		assert.InDelta(t, slowK[i], k[i], 1e-9)
+		assert.InDelta(t, sma3(slowK, i), d[i], 1e-9)
+	}
+
+	for _, s := range chart.MultiSeries {
+		if s.Name == "Stoch(5,3,3)-%K" {
+			assert.Contains(t, toJson(s.MarkLines.Data), `"name":"oversold","yAxis":20`)
+			assert.Contains(t, toJson(s.MarkLines.Data), `"name":"overbought","yAxis":80`)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package tachart
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/otetz/go-tachart/charts"
+)
+
+const (
+	// SSE stream path relative to the chart page served by Stream
+	streamPath = "stream"
+	// DOM event dispatched on the chart element once a pushed option is applied
+	liveUpdateEvent = "tachart-update"
+	// NOTE: js funcs are joined into a single line, statements must end with ';' and no '//' comments.
+	// Functions are marked by opts.FuncOpts as "__f__...__f__" in json, revive them into js functions.
+	// Only values of keys taking functions in tachart options are revived, so that marked user text,
+	// e.g. a label, is never run. Marker is split as the rendered page is stripped off such markers.
+	// Other js funcs may listen to the update event on the chart element, with the option as event detail.
+	liveUpdateFuncTpl = `
+		(function() {
+			var chart = %MY_ECHARTS%;
+			var marker = '__' + 'f__';
+			var funcKeys = {formatter: true, position: true, min: true, max: true, renderItem: true};
+			var revive = function(o, key) {
+				if (typeof o === 'string') {
+					if (funcKeys[key] === true && o.length >= 2*marker.length && o.startsWith(marker) && o.endsWith(marker)) {
+						return eval('(' + o.slice(marker.length, -marker.length) + ')');
+					}
+					return o;
+				}
+				if (o !== null && typeof o === 'object') {
+					for (var k in o) {
+						o[k] = revive(o[k], k);
+					}
+				}
+				return o;
+			};
+			var source = new EventSource('__STREAM_URL__');
+			source.onmessage = function(e) {
+				var opt = revive(JSON.parse(e.data));
+				chart.setOption(opt);
+				chart.getDom().dispatchEvent(new CustomEvent('__UPDATE_EVENT__', {detail: opt}));
+			};
+		})();`
+)
+
+// Stream holds candles and events of a live chart. Whenever candles or events are pushed,
+// indicators are updated and the changed parts of the chart option (x axis, series and tooltip)
+// are pushed to subscribed chart pages via server-sent events (SSE). Chart pages apply them in
+// place with setOption, keeping the current dataZoom window and tooltip.
+//
+// Indicators implementing Lookbacker, e.g. SMA, Bollinger bands of SMA, Donchian channels,
+// stochastic oscillator, MFI and CMF, are only recomputed from candles changed by a push, along
+// with the Lookback() candles before them. Other indicators are recomputed over all kept candles,
+// see SetMaxCandles.
+//
+// Stream serves the chart page and assets like Handler does, plus the SSE stream on "stream"
+// relative to the chart page, e.g.
+//
+//	s := tachart.NewStream(*cfg, cdls, events)
+//	http.Handle("/live/", http.StripPrefix("/live", s))
+//	...
+//	s.PushCandle(cdl)
+type Stream struct {
+	mu         sync.Mutex
+	chart      *TAChart
+	handler    *Handler
+	cdls       []Candle
+	events     []Event
+	maxCandles int
+	subs       map[chan []byte]struct{}
+	cache      *seriesCache
+}
+
+// NewStream creates a Stream with initial candles and events.
+func NewStream(cfg Config, cdls []Candle, events []Event) *Stream {
+	cfg.liveUpdateURL = streamPath
+	// trades, range events and indicator anchors fall out of the window as old candles are dropped
+	cfg.skipUnmatched = true
+	s := &Stream{
+		cdls:   append([]Candle{}, cdls...),
+		events: append([]Event{}, events...),
+		subs:   map[chan []byte]struct{}{},
+		cache:  &seriesCache{},
+	}
+	s.handler = NewHandler(cfg, s.snapshot)
+	s.chart = s.handler.chart
+	return s
+}
+
+// SetMaxCandles limits the # of candles kept, oldest candles are dropped once exceeded, along with
+// their events. Trades, range events and anchored indicators out of kept candles are not drawn.
+// 0 means no limit.
+func (s *Stream) SetMaxCandles(n int) *Stream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxCandles = n
+	return s
+}
+
+// PushCandle appends new candles. A candle of the same period (time or label) as the last
+// candle updates the last candle instead, e.g. the forming candle of current period.
+func (s *Stream) PushCandle(cdls ...Candle) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := append([]Candle{}, s.cdls...)
+	for _, cdl := range cdls {
+		if n := len(updated); n > 0 && sameCandle(updated[n-1], cdl) {
+			updated[n-1] = cdl
+		} else {
+			updated = append(updated, cdl)
+		}
+	}
+	events := s.events
+	if s.maxCandles > 0 && len(updated) > s.maxCandles {
+		var err error
+		if events, err = s.keptEvents(updated, len(updated)-s.maxCandles); err != nil {
+			return err
+		}
+		updated = updated[len(updated)-s.maxCandles:]
+	}
+
+	return s.update(updated, events)
+}
+
+// keptEvents returns events of cdls other than those of the first dropped candles.
+func (s *Stream) keptEvents(cdls []Candle, dropped int) ([]Event, error) {
+	axis, err := newCandleAxis(s.chart.cfg, cdls)
+	if err != nil {
+		return nil, err
+	}
+	kept := []Event{}
+	for _, e := range s.events {
+		if slot, err := axis.eventSlot(e); err == nil && slot < axis.slots[dropped] {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept, nil
+}
+
+// PushEvent appends new events.
+func (s *Stream) PushEvent(events ...Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := append([]Event{}, s.events...)
+	updated = append(updated, events...)
+
+	return s.update(s.cdls, updated)
+}
+
+func (s *Stream) update(cdls []Candle, events []Event) error {
+	msg, err := s.option(cdls, events)
+	if err != nil {
+		return err
+	}
+	s.cdls = cdls
+	s.events = events
+
+	for ch := range s.subs {
+		// only the latest option matters, drop the pending one if subscriber falls behind
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- msg
+	}
+	return nil
+}
+
+func (s *Stream) option(cdls []Candle, events []Event) ([]byte, error) {
+	chart, err := s.chart.genCachedChart(cdls, events, s.cache)
+	if err != nil {
+		return nil, err
+	}
+	chart.Validate()
+
+	// only parts changing with candles and events, the chart page keeps the rest, e.g. its zoom window
+	full := chart.JSON()
+	opt := map[string]interface{}{}
+	for _, k := range []string{"xAxis", "series", "tooltip"} {
+		opt[k] = full[k]
+	}
+	if cfg := s.chart.cfg; cfg.profileRows > 0 && cfg.profileRange == ProfileVisible {
+		// the chart page rebuilds the profile of its zoom window from the latest candles
+		axis, err := newCandleAxis(cfg, cdls)
+		if err != nil {
+			return nil, err
+		}
+		opt[profileCandlesKey] = profileCandles(cdls, axis.slots)
+	}
+	return []byte(strings.TrimSpace(toJson(opt))), nil
+}
+
+func (s *Stream) snapshot(_ *http.Request) ([]Candle, []Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Candle{}, s.cdls...), append([]Event{}, s.events...), nil
+}
+
+func (s *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/"+streamPath) {
+		s.serveEvents(w, r)
+		return
+	}
+	s.handler.ServeHTTP(w, r)
+}
+
+func (s *Stream) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// start with current option, in case of updates between page loading and subscribing
+	ch := make(chan []byte, 1)
+	s.mu.Lock()
+	msg, err := s.option(s.cdls, s.events)
+	if err != nil {
+		s.mu.Unlock()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ch <- msg
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-ch:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", msg); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// seriesCache keeps indicator series data of the last chart generated for a Stream, in the order
+// of indicators, before warm-up back-filling and gap expansion.
+type seriesCache struct {
+	cdls   []Candle
+	series [][]cachedSeries
+}
+
+type cachedSeries struct {
+	name string
+	data reflect.Value
+}
+
+// seriesUpdate builds indicator series of new candles from cached series, recomputing the tail
+// of series changed by new candles only.
+type seriesUpdate struct {
+	cache   *seriesCache
+	cdls    []Candle
+	dropped int // # of cached candles dropped from the front
+	first   int // index of the first changed or appended candle, -1 if cached series are of no use
+	series  [][]cachedSeries
+}
+
+// update starts building series of cdls, nil if there's no cache.
+func (sc *seriesCache) update(cdls []Candle) *seriesUpdate {
+	if sc == nil {
+		return nil
+	}
+	u := &seriesUpdate{cache: sc, cdls: cdls, first: -1}
+	if len(cdls) == 0 {
+		return u
+	}
+	for i, cdl := range sc.cdls {
+		if cdl != cdls[0] {
+			continue
+		}
+		u.dropped, u.first = i, 0
+		for u.first < len(cdls) && i+u.first < len(sc.cdls) && sc.cdls[i+u.first] == cdls[u.first] {
+			u.first++
+		}
+		break
+	}
+	return u
+}
+
+// tailStart returns the candle the next indicator is recomputed from, 0 to compute it over all candles.
+func (u *seriesUpdate) tailStart(ind Indicator) int {
+	if u == nil || u.first < 0 || len(u.series) >= len(u.cache.series) {
+		return 0
+	}
+	if _, ok := ind.(Displacer); ok {
+		return 0
+	}
+	l, ok := ind.(Lookbacker)
+	if !ok || l.Lookback() < 1 {
+		return 0
+	}
+	if start := u.first - l.Lookback() + 1; start > 0 && start < len(u.cdls) {
+		return start
+	}
+	return 0
+}
+
+// splice prepends cached data up to the first changed candle to series data recomputed from candle
+// start. It's false if series don't match the cached series of the indicator.
+func (u *seriesUpdate) splice(series charts.MultiSeries, start int) bool {
+	cached := u.cache.series[len(u.series)]
+	if len(series) != len(cached) {
+		return false
+	}
+	for i := range series {
+		v, c := reflect.ValueOf(series[i].Data), cached[i].data
+		if series[i].Name != cached[i].name || v.Kind() != reflect.Slice || c.Kind() != reflect.Slice ||
+			v.Type() != c.Type() || v.Len() != len(u.cdls)-start || c.Len() != len(u.cache.cdls) {
+			return false
+		}
+	}
+
+	// recomputed data of the first changed candle
+	tail := u.first - start
+	for i := range series {
+		v := reflect.ValueOf(series[i].Data)
+		data := reflect.MakeSlice(v.Type(), 0, len(u.cdls))
+		data = reflect.AppendSlice(data, cached[i].data.Slice(u.dropped, u.dropped+u.first))
+		data = reflect.AppendSlice(data, v.Slice(tail, v.Len()))
+		// the first Lookback()-1 values are blank as if computed over the kept candles only,
+		// like the first values recomputed
+		for k := 0; k < tail; k++ {
+			data.Index(k).Set(v.Index(k))
+		}
+		series[i].Data = data.Interface()
+	}
+	return true
+}
+
+// add caches series of the next indicator.
+func (u *seriesUpdate) add(series charts.MultiSeries) {
+	if u == nil {
+		return
+	}
+	cached := make([]cachedSeries, len(series))
+	for i, s := range series {
+		v := reflect.ValueOf(s.Data)
+		if v.Kind() == reflect.Slice {
+			v = reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v)
+		}
+		cached[i] = cachedSeries{name: s.Name, data: v}
+	}
+	u.series = append(u.series, cached)
+}
+
+// commit replaces the cache with series of the new candles.
+func (u *seriesUpdate) commit() {
+	if u == nil {
+		return
+	}
+	u.cache.cdls = append([]Candle{}, u.cdls...)
+	u.cache.series = u.series
+}
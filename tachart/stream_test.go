@@ -0,0 +1,183 @@
+package tachart
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+func readSSEData(t *testing.T, rd *bufio.Reader) map[string]interface{} {
+	for {
+		line, err := rd.ReadString('\n')
+		assert.NoError(t, err)
+		if strings.HasPrefix(line, "data: ") {
+			opt := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &opt))
+			return opt
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	s := NewStream(*testConfig(), testCdls[:20], testEvents)
+	mux := http.NewServeMux()
+	mux.Handle("/live/", http.StripPrefix("/live", s))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/live/")
+	assert.NoError(t, err)
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(page), "new EventSource('stream')")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/live/stream", nil)
+	resp, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	rd := bufio.NewReader(resp.Body)
+
+	opt := readSSEData(t, rd)
+	// only parts changing with candles are pushed
+	for _, k := range []string{"dataZoom", "title", "grid", "yAxis"} {
+		assert.NotContains(t, opt, k)
+	}
+	assert.Contains(t, opt, "series")
+	assert.Len(t, opt["xAxis"].([]interface{})[0].(map[string]interface{})["data"], 20)
+
+	assert.NoError(t, s.PushCandle(testCdls[20]))
+	opt = readSSEData(t, rd)
+	xAxis := opt["xAxis"].([]interface{})[0].(map[string]interface{})["data"].([]interface{})
+	assert.Len(t, xAxis, 21)
+	assert.Equal(t, testCdls[20].Label, xAxis[20])
+
+	// update the last candle
+	cdl := testCdls[20]
+	cdl.C += 10
+	assert.NoError(t, s.PushCandle(cdl))
+	opt = readSSEData(t, rd)
+	assert.Len(t, opt["xAxis"].([]interface{})[0].(map[string]interface{})["data"], 21)
+
+	assert.Equal(t, ErrDuplicateCandleLabel, s.PushCandle(testCdls[0]))
+	cdls, _, _ := s.snapshot(nil)
+	assert.Len(t, cdls, 21)
+}
+
+func TestStreamMaxCandles(t *testing.T) {
+	cfg := testConfig().
+		AddTrade(Trade{Side: Long, EntryLabel: testCdls[0].Label, EntryPrice: 2320, ExitLabel: testCdls[2].Label, ExitPrice: 2346}).
+		AddRangeEvent(RangeEvent{StartLabel: testCdls[0].Label, EndLabel: testCdls[1].Label})
+	events := []Event{
+		{Type: Long, Label: testCdls[0].Label},
+		{Type: Short, Label: testCdls[3].Label},
+	}
+	s := NewStream(*cfg, testCdls[:5], events).SetMaxCandles(5)
+	for _, cdl := range testCdls[5:8] {
+		assert.NoError(t, s.PushCandle(cdl))
+	}
+	cdls, kept, _ := s.snapshot(nil)
+	assert.Equal(t, testCdls[3:8], cdls)
+	assert.Equal(t, events[1:], kept)
+
+	// events dropped along with their candles
+	assert.NoError(t, s.PushCandle(testCdls[8]))
+	_, kept, _ = s.snapshot(nil)
+	assert.Empty(t, kept)
+}
+
+func TestStreamVolumeProfile(t *testing.T) {
+	s := NewStream(*testConfig().SetVolumeProfile(12, ProfileVisible), testCdls[:20], nil)
+	msg, err := s.option(testCdls[:20], nil)
+	assert.NoError(t, err)
+	opt := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(msg, &opt))
+	assert.Len(t, opt[profileCandlesKey], 20)
+
+	msg, err = NewStream(*testConfig(), testCdls[:20], nil).option(testCdls[:20], nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(msg), profileCandlesKey)
+}
+
+// lookbackSMA is a SMA keeping the # of candles it's computed over.
+type lookbackSMA struct {
+	Indicator
+	n *int
+}
+
+func (c lookbackSMA) Lookback() int {
+	return c.Indicator.(Lookbacker).Lookback()
+}
+
+func (c lookbackSMA) GenChart(opens, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	*c.n = len(closes)
+	return c.Indicator.GenChart(opens, highs, lows, closes, vols, xAxis, gridIndex)
+}
+
+func TestStreamSeriesCache(t *testing.T) {
+	n := 0
+	cfg := testConfig().
+		AddOverlay(lookbackSMA{NewSMA(3), &n}, NewHMA(4), NewDonchian(4, WithBandFill(""))).
+		AddIndicator(NewMFI(4, 20, 80), NewCMF(4)).
+		SetWarmUp(WarmUpBackfill)
+	c := New(*cfg)
+
+	updated := append([]Candle{}, testCdls[:22]...)
+	updated[21].C += 10
+	steps := []struct {
+		cdls []Candle
+		n    int // # of candles the SMA is computed over
+	}{
+		{testCdls[:20], 20},
+		// appended
+		{testCdls[:21], 3},
+		// last candle updated
+		{updated[:22], 3},
+		// oldest candles dropped, with the updated candle replaced
+		{testCdls[2:25], 6},
+		{testCdls[2:25], 2},
+		// unrelated candles
+		{testCdls[26:], 4},
+	}
+	cache := &seriesCache{}
+	for i, step := range steps {
+		got, err := c.genCachedChart(step.cdls, nil, cache)
+		assert.NoError(t, err)
+		assert.Equal(t, step.n, n, "step %v", i)
+		want, err := c.genChart(step.cdls, nil)
+		assert.NoError(t, err)
+
+		assert.Len(t, got.MultiSeries, len(want.MultiSeries))
+		for k, ws := range want.MultiSeries {
+			gs := got.MultiSeries[k]
+			assert.Equal(t, ws.Name, gs.Name)
+			wd, ok := ws.Data.([]opts.LineData)
+			if !ok {
+				assert.Equal(t, ws.Data, gs.Data)
+				continue
+			}
+			// values of running sums may differ in the last digits
+			gd := gs.Data.([]opts.LineData)
+			assert.Len(t, gd, len(wd))
+			for j := range wd {
+				if v, ok := wd[j].Value.(float64); ok {
+					assert.InDelta(t, v, gd[j].Value, 1e-6, "step %v %v[%v]", i, ws.Name, j)
+				} else {
+					assert.Equal(t, wd[j].Value, gd[j].Value, "step %v %v[%v]", i, ws.Name, j)
+				}
+			}
+		}
+	}
+}
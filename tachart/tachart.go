@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"math"
 	"os"
 	"strings"
 
 	"github.com/otetz/go-tachart/charts"
 	"github.com/otetz/go-tachart/components"
 	"github.com/otetz/go-tachart/opts"
+	"github.com/otetz/go-tachart/render"
 )
 
 const (
@@ -26,24 +29,51 @@ const (
 	tooltipFormatterFuncTpl = `
 		function(value) {
 			var eventMap = JSON.parse('__EVENT_MAP__');
+			var ohlcMap = JSON.parse('__OHLC_MAP__');
+			var rangeEvents = JSON.parse('__RANGE_EVENTS__');
 			var title = (sz,txt) => '<span style="display:inline;line-height:'+(sz+2)+'px;font-size:'+sz+'px;font-weight:bold;">'+txt+'</span>';
 			var square = (sz,sign,color,txt) => '<span style="display:inline;line-height:'+(sz+2)+'px;font-size:'+sz+'px;"><span style="display:inline-block;height:'+(sz+2)+'px;border-radius:3px;padding:1px 4px 1px 4px;text-align:center;margin-right:10px;background-color:' + color + ';vertical-align:top;">'+sign+'</span>'+txt+'</span>';
 			var wrap = (sz,txt,width) => '<span style="display:inline-block;width:'+width+'px;word-break:break-word;word-wrap:break-word;white-space:pre-wrap;line-height:'+(sz+2)+'px;font-size:'+sz+'px;">'+txt+'</span>';
 			var nowrap = (sz,txt) => '<span style="display:inline-block;line-height:'+(sz+2)+'px;font-size:'+sz+'px;">'+txt+'</span>';
 
+			var num = (v) => (typeof v === 'number' && !isNaN(v)) ? v.toFixed(__DECIMAL_PLACES__) : '-';
+
 			value.sort((a, b) => a.seriesIndex -b.seriesIndex);
-			var cdl = value[0];
+			var cdl = value.find(s => s.seriesName === 'kline') || value[0];
+			var ohlc = (cdl.seriesName === 'kline' && Array.isArray(cdl.value)) ? cdl.value : [];
+			var real = ohlcMap[cdl.dataIndex];
+			var price = (i) => {
+				if (!real) {
+					return num(ohlc[i]);
+				}
+				if (ohlc.length === 0) {
+					return num(real[i-1]);
+				}
+				return num(real[i-1]) + '  HA ' + num(ohlc[i]);
+			};
 			var ret = title(14, cdl.axisValueLabel)+ '  ['+cdl.dataIndex+']' + '<br/>' +
-			square(13,'O',cdl.color,cdl.value[1].toFixed(__DECIMAL_PLACES__)) + '<br/>' +
-			square(13,'C',cdl.color,cdl.value[2].toFixed(__DECIMAL_PLACES__)) + '<br/>' +
-			square(13,'L',cdl.color,cdl.value[3].toFixed(__DECIMAL_PLACES__)) + '<br/>' +
-			square(13,'H',cdl.color,cdl.value[4].toFixed(__DECIMAL_PLACES__)) + '<br/>';
-			for (var i = 1; i < value.length; i++) {
+			square(13,'O',cdl.color,price(1)) + '<br/>' +
+			square(13,'C',cdl.color,price(2)) + '<br/>' +
+			square(13,'L',cdl.color,price(3)) + '<br/>' +
+			square(13,'H',cdl.color,price(4)) + '<br/>';
+			for (var i = 0; i < value.length; i++) {
 				var s = value[i];
-				ret += square(13,s.seriesName,s.color,s.value.toFixed(__DECIMAL_PLACES__)) + '<br/>';
+				if (s.seriesName === 'kline') {
+					continue;
+				}
+				ret += square(13,s.seriesName,s.color,num(s.value)) + '<br/>';
 			}
 
-			var desc = eventMap[cdl.axisValueLabel];
+			var descs = [];
+			if (eventMap[cdl.dataIndex]) {
+				descs.push(eventMap[cdl.dataIndex]);
+			}
+			rangeEvents.forEach(r => {
+				if (r[2] && cdl.dataIndex >= r[0] && cdl.dataIndex <= r[1]) {
+					descs.push(r[2]);
+				}
+			});
+			var desc = descs.join('<br/>');
 			if (desc) {
 				if (__WRAP_DESC__) {
 					ret += '<hr>' + wrap(13,desc,__WRAP_WIDTH__);
@@ -65,10 +95,42 @@ const (
 		function(value) {
 			return value.toFixed(__DECIMAL_PLACES__);
 		}`
+	compactLabelFormatterFunc = `
+		function(value) {
+			var abs = Math.abs(value);
+			if (abs >= 1e9) {
+				return +(value/1e9).toFixed(2) + 'B';
+			}
+			if (abs >= 1e6) {
+				return +(value/1e6).toFixed(2) + 'M';
+			}
+			if (abs >= 1e3) {
+				return +(value/1e3).toFixed(2) + 'K';
+			}
+			return +value.toFixed(2) + '';
+		}`
+	minPadFunc = `
+		function(value) {
+			return value.min - (value.max - value.min) * 0.05;
+		}`
+	maxPadFunc = `
+		function(value) {
+			return value.max + (value.max - value.min) * 0.05;
+		}`
 )
 
 var (
 	ErrDuplicateCandleLabel = errors.New("candles with duplicated labels")
+	ErrDuplicateCandleTime  = errors.New("candles with duplicated time")
+	ErrUnsortedCandles      = errors.New("candles not sorted by time")
+	ErrUnknownEventCandle   = errors.New("event doesn't match any candle")
+	ErrUnknownTradeCandle   = errors.New("trade doesn't match any candle")
+
+	ErrUnknownRangeEventCandle = errors.New("range event doesn't match any candle")
+	ErrUnknownAnchorCandle     = errors.New("indicator anchor doesn't match any candle")
+	ErrInvalidTradeSide        = errors.New("trade side is neither Long nor Short")
+	ErrInvalidTradePrice       = errors.New("trade price is not positive")
+	ErrTradeExitBeforeEntry    = errors.New("trade exits before entry")
 
 	// TODO: complete the map for all themes
 	pageBgColorMap = map[Theme]string{
@@ -76,13 +138,13 @@ var (
 		ThemeVintage: "#FEF8EF",
 	}
 
-	// left margin
-	left = 80
-	// right margin
-	right   = 40
-	sliderH = 85
-	// vertical gap between charts
-	gap = 20
+	// default left margin
+	defaultLeft = 80
+	// default right margin
+	defaultRight   = 40
+	defaultSliderH = 85
+	// default vertical gap between charts
+	defaultGap = 20
 )
 
 type gridLayout struct {
@@ -93,7 +155,6 @@ type gridLayout struct {
 }
 
 type TAChart struct {
-	// TODO: support dynamic auto-refresh
 	cfg            Config
 	globalOptsData globalOptsData
 	extendedXAxis  []opts.XAxis
@@ -115,22 +176,41 @@ func New(cfg Config) *TAChart {
 		tooltipFormatterFunc = strings.Replace(tooltipFormatterFunc, "__WRAP_WIDTH__", fmt.Sprintf("%v", cfg.eventDescWrapWidth), -1)
 	}
 
-	// grid layuout: N = len(indicators) + 1
+	// grid layuout: heights are split by weights of charts, 2:1:...:1 by default
 	// ----------------------------------------
-	//   candlestick chart + overlay + events (h/2)
+	//   candlestick chart + overlay + events (price weight)
 	// ----------------------------------------
-	//   		indicator chart               (h/2/N)
+	//   		indicator chart               (indicator weight)
 	//   			...
-	//   		indicator chart               (h/2/N)
+	//   		indicator chart               (indicator weight)
 	// ----------------------------------------
-	//   		  volume chart                (h/2/N)
+	//   		  volume chart                (volume weight, optional)
+	// ----------------------------------------
+	//   		  slider                      (optional)
 	// ----------------------------------------
 
-	h := (cfg.layout.chartHeight - sliderH) / (len(cfg.indicators) + 1 + 2)
+	left := cfg.leftMargin
+	right := cfg.rightMargin
+	gap := cfg.paneGap
+	sliderH := cfg.sliderHeight
+	if cfg.hideSlider {
+		sliderH = gap
+	}
+	weights := append([]float64{}, cfg.indicatorWeights...)
+	if !cfg.hideVolume {
+		weights = append(weights, cfg.volumeWeight)
+	}
+	totalWeight := cfg.priceWeight
+	for _, w := range weights {
+		totalWeight += w
+	}
+	unit := float64(cfg.layout.chartHeight-sliderH) / totalWeight
+
+	priceH := int(unit * cfg.priceWeight)
 	// candlestick+overlay
 	cdlChartTop := 20
 	// event
-	eventChartTop := cdlChartTop + h*2 - 30
+	eventChartTop := cdlChartTop + priceH - 30
 	eventChartH := 10
 
 	grids := []opts.Grid{
@@ -138,7 +218,7 @@ func New(cfg Config) *TAChart {
 			Left:   px(left),
 			Right:  px(right),
 			Top:    px(cdlChartTop),
-			Height: px(h * 2),
+			Height: px(priceH),
 		},
 		{ // event
 			Left:   px(left),
@@ -152,7 +232,7 @@ func New(cfg Config) *TAChart {
 			top:  cdlChartTop,
 			left: left,
 			w:    right - left,
-			h:    h * 2,
+			h:    priceH,
 		},
 		{
 			top:  eventChartTop,
@@ -176,9 +256,10 @@ func New(cfg Config) *TAChart {
 	}
 
 	// indicator & vol chart, inddex starting from 2
-	top := cdlChartTop + h*2 + gap*2
-	for i := 0; i < len(cfg.indicators)+1; i++ {
+	top := cdlChartTop + priceH + gap*2
+	for i, w := range weights {
 		gridIndex := i + 2
+		h := int(unit * w)
 		grids = append(grids, opts.Grid{
 			Left:   px(left),
 			Right:  px(right),
@@ -214,17 +295,17 @@ func New(cfg Config) *TAChart {
 		if i == len(cfg.indicators) {
 			// volume
 			min = "0"
-			indYLabelFormatterFunc = strings.Replace(yLabelFormatterFuncTpl, "__DECIMAL_PLACES__", "0", -1)
+			indYLabelFormatterFunc = compactLabelFormatterFunc
 		} else {
-			v := cfg.indicators[i].yAxisLabel()
+			v := cfg.indicators[i].YAxisLabel()
 			if v != "" {
 				indYLabelFormatterFunc = v
 			}
-			v = cfg.indicators[i].yAxisMin()
+			v = cfg.indicators[i].YAxisMin()
 			if v != "" {
 				min = v
 			}
-			v = cfg.indicators[i].yAxisMax()
+			v = cfg.indicators[i].YAxisMax()
 			if v != "" {
 				max = v
 			}
@@ -303,7 +384,10 @@ func New(cfg Config) *TAChart {
 			},
 		},
 	}
-	if cfg.draggable {
+	if cfg.hideSlider {
+		globalOptsData.dataZooms = nil
+	}
+	if cfg.draggable || cfg.hideSlider {
 		globalOptsData.dataZooms = append(globalOptsData.dataZooms,
 			opts.DataZoom{
 				Type:       "inside",
@@ -317,23 +401,26 @@ func New(cfg Config) *TAChart {
 	top = layout.top - 5
 	ci := 0
 	for _, ol := range cfg.overlays {
-		globalOptsData.titles = append(globalOptsData.titles, ol.getTitleOpts(top, layout.left+5, ci)...)
-		top += chartLabelFontHeight
-		ci += ol.getNumColors()
+		titles := ol.GetTitleOpts(top, layout.left+5, ci)
+		globalOptsData.titles = append(globalOptsData.titles, titles...)
+		top += chartLabelFontHeight * len(titles)
+		ci += ol.GetNumColors()
 	}
 	for i, ind := range cfg.indicators {
 		indLayout := gridLayouts[i+2]
-		globalOptsData.titles = append(globalOptsData.titles, ind.getTitleOpts(indLayout.top-5, indLayout.left+5, 0)...)
+		globalOptsData.titles = append(globalOptsData.titles, ind.GetTitleOpts(indLayout.top-5, indLayout.left+5, 0)...)
+	}
+	if !cfg.hideVolume {
+		layout = gridLayouts[len(gridLayouts)-1]
+		globalOptsData.titles = append(globalOptsData.titles, opts.Title{
+			TitleStyle: &opts.TextStyle{
+				FontSize: chartLabelFontSize,
+			},
+			Title: "Vol",
+			Left:  px(layout.left + 5),
+			Top:   px(layout.top - 5),
+		})
 	}
-	layout = gridLayouts[len(gridLayouts)-1]
-	globalOptsData.titles = append(globalOptsData.titles, opts.Title{
-		TitleStyle: &opts.TextStyle{
-			FontSize: chartLabelFontSize,
-		},
-		Title: "Vol",
-		Left:  px(layout.left + 5),
-		Top:   px(layout.top - 5),
-	})
 
 	return &TAChart{
 		cfg:            cfg,
@@ -344,24 +431,125 @@ func New(cfg Config) *TAChart {
 	}
 }
 
+// GenStatic renders the chart page into a static html file.
 func (c TAChart) GenStatic(cdls []Candle, events []Event, path string) error {
-	xAxis := make([]string, 0)
-	klineSeries := []opts.KlineData{}
+	fp, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	return c.Render(fp, cdls, events)
+}
+
+// Render renders the chart page into w.
+func (c TAChart) Render(w io.Writer, cdls []Candle, events []Event) error {
+	page, err := c.genPage(cdls, events)
+	if err != nil {
+		return err
+	}
+	return page.Render(w)
+}
+
+// RenderContent renders the chart page and returns the html content.
+func (c TAChart) RenderContent(cdls []Candle, events []Event) ([]byte, error) {
+	page, err := c.genPage(cdls, events)
+	if err != nil {
+		return nil, err
+	}
+	return page.RenderContent(), nil
+}
+
+// RenderSnippet renders the chart only (without the page layout) as an element and
+// a script to be embedded into an existing html page. The hosting page is responsible
+// for loading echarts.min.js and the theme script from the assets host.
+func (c TAChart) RenderSnippet(cdls []Candle, events []Event) (render.ChartSnippet, error) {
+	chart, err := c.genChart(cdls, events)
+	if err != nil {
+		return render.ChartSnippet{}, err
+	}
+	return chart.RenderSnippet(), nil
+}
+
+func (c TAChart) genPage(cdls []Candle, events []Event) (*components.Page, error) {
+	chart, err := c.genChart(cdls, events)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := components.Layout{
+		TemplateColumns: template.CSS(fmt.Sprintf("%vpx %vpx %vpx", c.cfg.layout.leftWidth, c.cfg.layout.chartWidth, c.cfg.layout.rightWidth)),
+		TopHeight:       template.CSS(px(c.cfg.layout.topHeight)),
+		BottomHeight:    template.CSS(px(c.cfg.layout.bottomHeight)),
+		TopContent:      template.HTML(c.cfg.layout.topContent),
+		BottomContent:   template.HTML(c.cfg.layout.bottomContent),
+		LeftContent:     template.HTML(c.cfg.layout.leftContent),
+		RightContent:    template.HTML(c.cfg.layout.rightContent),
+	}
+
+	pageBgColor := pageBgColorMap[c.cfg.theme]
+	if pageBgColor == "" {
+		pageBgColor = "#FFFFFF"
+	}
+
+	return components.NewPage(c.cfg.assetsHost).
+		SetLayout(layout).
+		SetBackgroundColor(pageBgColor).
+		AddCharts(chart), nil
+}
+
+func (c TAChart) genChart(cdls []Candle, events []Event) (*charts.Kline, error) {
+	return c.genCachedChart(cdls, events, nil)
+}
+
+// genCachedChart is genChart recomputing indicators from the candles changed since the last chart
+// generated with cache, if they implement Lookbacker. cache is updated with the new chart, nil means
+// indicators are computed over all candles.
+func (c TAChart) genCachedChart(cdls []Candle, events []Event, cache *seriesCache) (*charts.Kline, error) {
+	if c.cfg.heikinAshi && c.cfg.haIndicators {
+		// Heikin-Ashi candles depend on all prior candles, which change as old candles are dropped
+		cache = nil
+	}
+	axis, err := newCandleAxis(c.cfg, cdls)
+	if err != nil {
+		return nil, err
+	}
+	future := 0
+	for _, ind := range append(append([]Indicator{}, c.cfg.overlays...), c.cfg.indicators...) {
+		if d, ok := ind.(Displacer); ok && d.Displacement() > future {
+			future = d.Displacement()
+		}
+	}
+	axis.extend(future)
+	xAxis := axis.labels
+
 	volSeries := []opts.BarData{}
 	opens := []float64{}
 	highs := []float64{}
 	lows := []float64{}
 	closes := []float64{}
 	vols := []float64{}
-	cdlMap := map[string]*Candle{}
-	for _, cdl := range cdls {
-		xAxis = append(xAxis, cdl.Label)
-		// open,close,low,high
-		klineSeries = append(klineSeries, opts.KlineData{Value: []float64{cdl.O, cdl.C, cdl.L, cdl.H}})
-		opens = append(opens, cdl.O)
-		highs = append(highs, cdl.H)
-		lows = append(lows, cdl.L)
-		closes = append(closes, cdl.C)
+	// candles drawn and candles indicators are computed on, which are the real candles
+	// unless Heikin-Ashi candles are drawn
+	priceCdls := cdls
+	indCdls := cdls
+	if c.cfg.heikinAshi {
+		priceCdls = heikinAshi(cdls)
+		if c.cfg.haIndicators {
+			indCdls = priceCdls
+		}
+	}
+	// real OHLC shown in tooltip, needed unless real OHLC values are drawn
+	ohlcMap := map[int][]float64{}
+	for i, cdl := range cdls {
+		if c.cfg.heikinAshi || !c.cfg.priceStyle.hasOHLC() {
+			ohlcMap[axis.slots[i]] = []float64{cdl.O, cdl.C, cdl.L, cdl.H}
+		}
+		ic := indCdls[i]
+		opens = append(opens, ic.O)
+		highs = append(highs, ic.H)
+		lows = append(lows, ic.L)
+		closes = append(closes, ic.C)
 		vols = append(vols, cdl.V)
 
 		style := &opts.ItemStyle{
@@ -378,44 +566,103 @@ func (c TAChart) GenStatic(cdls []Candle, events []Event, path string) error {
 			Value:     cdl.V,
 			ItemStyle: style,
 		})
+	}
 
-		if cdlMap[cdl.Label] != nil {
-			return ErrDuplicateCandleLabel
+	eventSlots := []int{}
+	for _, e := range events {
+		slot, err := axis.eventSlot(e)
+		if err != nil {
+			return nil, err
+		}
+		eventSlots = append(eventSlots, slot)
+	}
+
+	for _, inds := range [][]Indicator{c.cfg.overlays, c.cfg.indicators} {
+		for _, ind := range inds {
+			a, ok := ind.(anchoredIndicator)
+			if !ok {
+				continue
+			}
+			if e, anchored := a.anchorEvent(); anchored && !c.cfg.skipUnmatched {
+				if _, err := axis.eventSlot(e); err != nil {
+					return nil, ErrUnknownAnchorCandle
+				}
+			}
 		}
-		c := cdl
-		cdlMap[cdl.Label] = &c
 	}
 
 	// candlestick+overlay
-	chart := charts.NewKLine().SetXAxis(xAxis).AddSeries("kline",
-		klineSeries,
-		charts.WithKlineChartOpts(opts.KlineChart{
-			BarWidth: "60%",
-		}),
-		charts.WithItemStyleOpts(opts.ItemStyle{
-			Color:        colorUpBar,
-			Color0:       colorDownBar,
-			BorderColor:  colorUpBar,
-			BorderColor0: colorDownBar,
-			Opacity:      opacityHeavy,
-		}),
-	)
+	chart := genPriceChart(c.cfg.priceStyle, priceCdls, axis.slots, xAxis)
 
-	eventDescMap := map[string]string{}
-	for _, e := range events {
-		eventDescMap[e.Label] = e.Description
+	eventDescMap := map[int]string{}
+	for i, e := range events {
+		eventDescMap[eventSlots[i]] = e.Description
+	}
+	var trades charts.Overlaper
+	if len(c.cfg.trades) > 0 {
+		// trade descriptions are shown along with event descriptions
+		if trades, err = genTradeChart(c.cfg.trades, axis, c.cfg.precision, eventDescMap, c.cfg.skipUnmatched); err != nil {
+			return nil, err
+		}
 	}
 
-	chart.SetGlobalOptions(c.globalOptsData.genOpts(c.cfg, len(cdls), eventDescMap)...)
+	rangeSpans := [][]interface{}{}
+	if len(c.cfg.rangeEvents) > 0 {
+		numPanes := len(c.cfg.indicators)
+		if !c.cfg.hideVolume {
+			numPanes++
+		}
+		var rangeEvents charts.Overlaper
+		if rangeEvents, rangeSpans, err = genRangeEventChart(c.cfg.rangeEvents, axis, numPanes, c.cfg.skipUnmatched); err != nil {
+			return nil, err
+		}
+		chart.Overlap(rangeEvents)
+	}
+
+	chart.SetGlobalOptions(c.globalOptsData.genOpts(c.cfg, len(xAxis), eventDescMap, ohlcMap, rangeSpans)...)
+
+	labeled := make([]Candle, len(cdls))
+	for i, cdl := range indCdls {
+		cdl.Label = xAxis[axis.slots[i]]
+		labeled[i] = cdl
+	}
+	update := cache.update(cdls)
+	overlapIndicator := func(ind Indicator, gridIndex int) {
+		n := len(chart.MultiSeries)
+		gen := func(start int) {
+			if ci, ok := ind.(CandleIndicator); ok {
+				chart.Overlap(ci.GenCandleChart(labeled[start:], xAxis, gridIndex))
+			} else {
+				chart.Overlap(ind.GenChart(opens[start:], highs[start:], lows[start:], closes[start:], vols[start:], xAxis, gridIndex))
+			}
+		}
+		if start := update.tailStart(ind); start > 0 {
+			gen(start)
+			if !update.splice(chart.MultiSeries[n:], start) {
+				chart.MultiSeries = chart.MultiSeries[:n]
+				gen(0)
+			}
+		} else {
+			gen(0)
+		}
+		// cached before back-filling, which changes data in place
+		update.add(chart.MultiSeries[n:])
+		if c.cfg.warmUp == WarmUpBackfill {
+			backfillWarmUp(ind, chart.MultiSeries[n:])
+		}
+	}
 
 	for _, ol := range c.cfg.overlays {
-		chart.Overlap(ol.genChart(opens, highs, lows, closes, vols, xAxis, 0))
+		overlapIndicator(ol, 0)
 	}
 
-	for i := 0; i < len(c.extendedXAxis); i++ {
-		c.extendedXAxis[i].Data = xAxis
+	// copy before filling data, as charts of the same TAChart can be generated concurrently
+	extendedXAxis := make([]opts.XAxis, len(c.extendedXAxis))
+	for i, xa := range c.extendedXAxis {
+		xa.Data = xAxis
+		extendedXAxis[i] = xa
 	}
-	chart.ExtendXAxis(c.extendedXAxis...)
+	chart.ExtendXAxis(extendedXAxis...)
 	chart.ExtendYAxis(c.extendedYAxis...)
 
 	evtOpts := []charts.SeriesOpts{
@@ -425,63 +672,68 @@ func (c TAChart) GenStatic(cdls []Candle, events []Event, path string) error {
 			YAxisIndex: 1,
 		}),
 	}
-	for _, e := range events {
-		es := eventLabelMap[e.Type]
-		if e.Type == CustomEvent {
-			es = e.EventMark.toEventStyle()
+	priceEvtOpts := []charts.SeriesOpts{}
+	for i, e := range events {
+		if e.Price != 0 {
+			priceEvtOpts = append(priceEvtOpts, charts.WithMarkPointNameCoordItemOpts(e.priceMarkPoint(eventSlots[i])))
+			continue
 		}
-		evtOpts = append(evtOpts, charts.WithMarkPointNameCoordItemOpts(opts.MarkPointNameCoordItem{
-			Symbol:     "roundRect",
-			SymbolSize: es.symbolSize,
-			Coordinate: []interface{}{e.Label, 0},
-			Label:      es.label,
-			ItemStyle:  es.style,
-		}))
+		evtOpts = append(evtOpts, charts.WithMarkPointNameCoordItemOpts(e.stripMarkPoint(eventSlots[i])))
+	}
+	if len(c.cfg.levels) > 0 || len(c.cfg.pivots) > 0 {
+		chart.Overlap(genLevelChart(c.cfg.levels, c.cfg.pivots, cdls, axis.slots, c.cfg.precision))
+	}
+	if trades != nil {
+		chart.Overlap(trades)
+	}
+	if len(priceEvtOpts) > 0 {
+		chart.Overlap(charts.NewScatter().AddSeries("price-events", []opts.ScatterData{}, priceEvtOpts...))
 	}
 	event := charts.NewBar().AddSeries("events", []opts.BarData{}, evtOpts...)
 	chart.Overlap(event)
 
 	// grid index starting from 2 (candlestick+event)
 	for i, ind := range c.cfg.indicators {
-		chart.Overlap(ind.genChart(opens, highs, lows, closes, vols, xAxis, i+2))
+		overlapIndicator(ind, i+2)
 	}
 
-	bar := charts.NewBar().
-		SetXAxis(xAxis).
-		AddSeries("Vol", volSeries, charts.WithBarChartOpts(opts.BarChart{
-			BarWidth:   "60%",
-			XAxisIndex: len(c.cfg.indicators) + 2,
-			YAxisIndex: len(c.cfg.indicators) + 2,
-		}))
-	chart.Overlap(bar)
-	chart.AddJSFuncs(c.cfg.jsFuncs...)
-
-	fp, err := os.Create(path)
-	if err != nil {
-		return err
+	if !c.cfg.hideVolume {
+		bar := charts.NewBar().
+			SetXAxis(xAxis).
+			AddSeries("Vol", volSeries, charts.WithBarChartOpts(opts.BarChart{
+				BarWidth:   "60%",
+				XAxisIndex: len(c.cfg.indicators) + 2,
+				YAxisIndex: len(c.cfg.indicators) + 2,
+			}))
+		chart.Overlap(bar)
 	}
-	defer fp.Close()
-
-	layout := components.Layout{
-		TemplateColumns: template.CSS(fmt.Sprintf("%vpx %vpx %vpx", c.cfg.layout.leftWidth, c.cfg.layout.chartWidth, c.cfg.layout.rightWidth)),
-		TopHeight:       template.CSS(px(c.cfg.layout.topHeight)),
-		BottomHeight:    template.CSS(px(c.cfg.layout.bottomHeight)),
-		TopContent:      template.HTML(c.cfg.layout.topContent),
-		BottomContent:   template.HTML(c.cfg.layout.bottomContent),
-		LeftContent:     template.HTML(c.cfg.layout.leftContent),
-		RightContent:    template.HTML(c.cfg.layout.rightContent),
+	axis.expandSeries(chart.MultiSeries)
+
+	if c.cfg.profileRows > 0 {
+		profileCdls := cdls
+		if c.cfg.profileRange == ProfileVisible {
+			// candles in the initial dataZoom window
+			first := int(math.Floor(float64(100-zoomPercent(c.cfg, len(xAxis))) / 100 * float64(len(xAxis)-1)))
+			profileCdls = nil
+			for i, cdl := range cdls {
+				if axis.slots[i] >= first {
+					profileCdls = append(profileCdls, cdl)
+				}
+			}
+			chart.AddJSFuncs(profileUpdateFunc(cdls, axis.slots, c.cfg.profileRows, c.cfg.valueArea))
+		}
+		profile := newVolumeProfile(profileCdls, c.cfg.profileRows, c.cfg.valueArea)
+		chart.Overlap(genVolumeProfileChart(profile, len(chart.XAxisList)))
+		chart.ExtendXAxis(profileXAxis())
 	}
-
-	pageBgColor := pageBgColorMap[c.cfg.theme]
-	if pageBgColor == "" {
-		pageBgColor = "#FFFFFF"
+	chart.AddJSFuncs(c.cfg.jsFuncs...)
+	update.commit()
+	if c.cfg.liveUpdateURL != "" {
+		liveUpdateFunc := strings.Replace(liveUpdateFuncTpl, "__STREAM_URL__", c.cfg.liveUpdateURL, -1)
+		chart.AddJSFuncs(strings.Replace(liveUpdateFunc, "__UPDATE_EVENT__", liveUpdateEvent, -1))
 	}
 
-	return components.NewPage(c.cfg.assetsHost).
-		SetLayout(layout).
-		SetBackgroundColor(pageBgColor).
-		AddCharts(chart).
-		Render(fp)
+	return chart, nil
 }
 
 func px(v int) string {
@@ -0,0 +1,229 @@
+package tachart
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/otetz/go-tachart/opts"
+)
+
+var testCdls = []Candle{
+	{Label: "2018/1/24", O: 2320.26, C: 2320.26, L: 2287.3, H: 2362.94, V: 149092},
+	{Label: "2018/1/25", O: 2300, C: 2291.3, L: 2288.26, H: 2308.38, V: 189092},
+	{Label: "2018/1/28", O: 2295.35, C: 2346.5, L: 2295.35, H: 2346.92, V: 159034},
+	{Label: "2018/1/29", O: 2347.22, C: 2358.98, L: 2337.35, H: 2363.8, V: 249910},
+	{Label: "2018/1/30", O: 2360.75, C: 2382.48, L: 2347.89, H: 2383.76, V: 119910},
+	{Label: "2018/1/31", O: 2383.43, C: 2385.42, L: 2371.23, H: 2391.82, V: 89940},
+	{Label: "2018/2/1", O: 2377.41, C: 2419.02, L: 2369.57, H: 2421.15, V: 192941},
+	{Label: "2018/2/4", O: 2425.92, C: 2428.15, L: 2417.58, H: 2440.38, V: 249410},
+	{Label: "2018/2/5", O: 2411, C: 2433.13, L: 2403.3, H: 2437.42, V: 149410},
+	{Label: "2018/2/6", O: 2432.68, C: 2434.48, L: 2427.7, H: 2441.73, V: 149910},
+	{Label: "2018/2/7", O: 2430.69, C: 2418.53, L: 2394.22, H: 2433.89, V: 249910},
+	{Label: "2018/2/8", O: 2416.62, C: 2432.4, L: 2414.4, H: 2443.03, V: 149410},
+	{Label: "2018/2/18", O: 2441.91, C: 2421.56, L: 2415.43, H: 2444.8, V: 249910},
+	{Label: "2018/2/19", O: 2420.26, C: 2382.91, L: 2373.53, H: 2427.07, V: 149910},
+	{Label: "2018/2/20", O: 2383.49, C: 2397.18, L: 2370.61, H: 2397.94, V: 149910},
+	{Label: "2018/2/21", O: 2378.82, C: 2325.95, L: 2309.17, H: 2378.82, V: 449910},
+	{Label: "2018/2/22", O: 2322.94, C: 2314.16, L: 2308.76, H: 2330.88, V: 149910},
+	{Label: "2018/2/25", O: 2320.62, C: 2325.82, L: 2315.01, H: 2338.78, V: 249910},
+	{Label: "2018/2/26", O: 2313.74, C: 2293.34, L: 2289.89, H: 2340.71, V: 249940},
+	{Label: "2018/2/27", O: 2297.77, C: 2313.22, L: 2292.03, H: 2324.63, V: 149944},
+	{Label: "2018/2/28", O: 2322.32, C: 2365.59, L: 2308.92, H: 2366.16, V: 249910},
+	{Label: "2018/3/1", O: 2364.54, C: 2359.51, L: 2330.86, H: 2369.65, V: 249914},
+	{Label: "2018/3/4", O: 2332.08, C: 2273.4, L: 2259.25, H: 2333.54, V: 241910},
+	{Label: "2018/3/5", O: 2274.81, C: 2326.31, L: 2270.1, H: 2328.14, V: 249910},
+	{Label: "2018/3/6", O: 2333.61, C: 2347.18, L: 2321.6, H: 2351.44, V: 241911},
+	{Label: "2018/3/7", O: 2340.44, C: 2324.29, L: 2304.27, H: 2352.02, V: 249910},
+	{Label: "2018/3/8", O: 2326.42, C: 2318.61, L: 2314.59, H: 2333.67, V: 249110},
+	{Label: "2018/3/11", O: 2314.68, C: 2310.59, L: 2296.58, H: 2320.96, V: 249910},
+	{Label: "2018/3/12", O: 2309.16, C: 2286.6, L: 2264.83, H: 2333.29, V: 241940},
+	{Label: "2018/3/13", O: 2282.17, C: 2263.97, L: 2253.25, H: 2286.33, V: 249911},
+}
+
+var testEvents = []Event{
+	{
+		Type:        Long,
+		Label:       testCdls[10].Label,
+		Description: "go long on " + testCdls[10].Label,
+	},
+}
+
+func testConfig() *Config {
+	return NewConfig().
+		AddOverlay(NewSMA(5), NewBBandsSMA(5, 2)).
+		AddIndicator(NewMACD(3, 6, 2), NewRSI(5, 30, 70), NewStoch(5, 3, 3, 20, 80))
+}
+
+func TestRender(t *testing.T) {
+	c := New(*testConfig())
+
+	var buf bytes.Buffer
+	err := c.Render(&buf, testCdls, testEvents)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "<html>")
+	assert.Contains(t, buf.String(), "echarts.min.js")
+	assert.Contains(t, buf.String(), "SMA(5)")
+
+	content, err := c.RenderContent(testCdls, testEvents)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "<html>")
+}
+
+func TestRenderSnippet(t *testing.T) {
+	c := New(*testConfig())
+
+	snippet, err := c.RenderSnippet(testCdls, testEvents)
+	assert.NoError(t, err)
+	assert.NotContains(t, snippet.Element, "<html>")
+	assert.Contains(t, snippet.Element, "<div")
+	assert.Contains(t, snippet.Script, "setOption")
+	assert.Contains(t, snippet.Option, "RSI(5)")
+	assert.Contains(t, snippet.Option, "Stoch(5,3,3)-%D")
+}
+
+func TestRenderDuplicateLabel(t *testing.T) {
+	c := New(*testConfig())
+
+	cdls := append([]Candle{}, testCdls...)
+	cdls = append(cdls, cdls[len(cdls)-1])
+	err := c.Render(&bytes.Buffer{}, cdls, nil)
+	assert.Equal(t, ErrDuplicateCandleLabel, err)
+}
+
+func sliceLen(data interface{}) int {
+	return reflect.ValueOf(data).Len()
+}
+
+func TestWarmUp(t *testing.T) {
+	chart, err := New(*testConfig()).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	sma := seriesValues(t, chart.MultiSeries, "SMA(5)")
+	assert.Equal(t, "-", sma[3])
+	assert.IsType(t, 0.0, sma[4])
+	rsi := seriesValues(t, chart.MultiSeries, "RSI(5)")
+	assert.Equal(t, "-", rsi[4])
+	assert.IsType(t, 0.0, rsi[5])
+	macd := seriesValues(t, chart.MultiSeries, "MACD(3,6,2)-Diff")
+	assert.Equal(t, "-", macd[5])
+	assert.IsType(t, 0.0, macd[6])
+
+	chart, err = New(*testConfig().SetWarmUp(WarmUpBackfill)).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	sma = seriesValues(t, chart.MultiSeries, "SMA(5)")
+	assert.Equal(t, sma[4], sma[0])
+	rsi = seriesValues(t, chart.MultiSeries, "RSI(5)")
+	assert.Equal(t, rsi[5], rsi[0])
+
+	// blanks before the anchor and over the displacement are not warm-up
+	cfg := NewConfig().
+		SetWarmUp(WarmUpBackfill).
+		AddOverlay(NewAnchoredVWAP(testCdls[10].Label), NewIchimoku(3, 5, 10))
+	chart, err = New(*cfg).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	avwap := seriesValues(t, chart.MultiSeries, "AVWAP("+testCdls[10].Label+")")
+	assert.Equal(t, "-", avwap[9])
+	assert.IsType(t, 0.0, avwap[10])
+	tenkan := seriesValues(t, chart.MultiSeries, "Ichimoku(3,5,10)-Tenkan")
+	assert.Equal(t, tenkan[2], tenkan[0])
+	senkouA := seriesValues(t, chart.MultiSeries, "Ichimoku(3,5,10)-SenkouA")
+	assert.Equal(t, "-", senkouA[0])
+}
+
+func TestLayout(t *testing.T) {
+	cfg := NewConfig().
+		AddIndicator(NewMACD(3, 6, 2)).
+		AddIndicatorWithWeight(2, NewRSI(5, 30, 70)).
+		SetPriceWeight(4).
+		SetShowVolume(false).
+		SetShowSlider(false).
+		SetMargins(60, 30)
+	chart, err := New(*cfg).genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	// candlestick, event, MACD, RSI
+	assert.Len(t, chart.GridList, 4)
+	assert.Equal(t, "274px", chart.GridList[0].Height)
+	assert.Equal(t, "48px", chart.GridList[2].Height)
+	assert.Equal(t, "117px", chart.GridList[3].Height)
+	assert.Equal(t, "60px", chart.GridList[0].Left)
+	assert.Equal(t, "30px", chart.GridList[3].Right)
+	for _, s := range chart.MultiSeries {
+		assert.NotEqual(t, "Vol", s.Name)
+	}
+	assert.Len(t, chart.DataZoomList, 1)
+	assert.Equal(t, "inside", chart.DataZoomList[0].Type)
+
+	// default layout: 2:1:...:1
+	chart, err = New(*testConfig()).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	assert.Len(t, chart.GridList, 6)
+	assert.Equal(t, "138px", chart.GridList[0].Height)
+	assert.Equal(t, "49px", chart.GridList[5].Height)
+	assert.Equal(t, "slider", chart.DataZoomList[0].Type)
+
+	// weights not > 0 are ignored
+	cfg = NewConfig().SetPriceWeight(0).SetVolumeWeight(-1).SetShowVolume(false)
+	assert.Equal(t, 2.0, cfg.priceWeight)
+	assert.Equal(t, 1.0, cfg.volumeWeight)
+	chart, err = New(*cfg).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, New(*NewConfig().SetShowVolume(false)).globalOptsData.grids[0].Height, chart.GridList[0].Height)
+	assert.Equal(t, []float64{1}, NewConfig().AddIndicatorWithWeight(-2, NewRSI(5, 30, 70)).indicatorWeights)
+}
+
+func TestPriceEvents(t *testing.T) {
+	events := []Event{
+		{Type: Long, Label: testCdls[3].Label, Price: testCdls[3].L},
+		{Type: Short, Label: testCdls[8].Label, Price: testCdls[8].H},
+		{Type: Close, Label: testCdls[10].Label},
+	}
+	chart, err := New(*testConfig()).genChart(testCdls, events)
+	assert.NoError(t, err)
+
+	var priceMarks, stripMarks []interface{}
+	for _, s := range chart.MultiSeries {
+		switch s.Name {
+		case "price-events":
+			priceMarks = s.MarkPoints.Data
+		case "events":
+			stripMarks = s.MarkPoints.Data
+		}
+	}
+	assert.Len(t, stripMarks, 1)
+	assert.Len(t, priceMarks, 2)
+
+	long := priceMarks[0].(opts.MarkPointNameCoordItem)
+	assert.Equal(t, []interface{}{3, testCdls[3].L}, long.Coordinate)
+	assert.Equal(t, "arrow", long.Symbol)
+	assert.Equal(t, float32(0), long.SymbolRotate)
+	short := priceMarks[1].(opts.MarkPointNameCoordItem)
+	assert.Equal(t, float32(180), short.SymbolRotate)
+	// shared styles of event types are not modified
+	assert.Equal(t, "", eventLabelMap[Long].label.Position)
+}
+
+func TestRangeEvents(t *testing.T) {
+	cfg := testConfig().AddRangeEvent(
+		RangeEvent{StartLabel: testCdls[5].Label, EndLabel: testCdls[9].Label, Description: "earnings"},
+		RangeEvent{StartLabel: testCdls[20].Label, EndLabel: testCdls[15].Label, Color: "#FF0000", AllPanes: true},
+	)
+	chart, err := New(*cfg).genChart(testCdls, testEvents)
+	assert.NoError(t, err)
+
+	// candlestick chart, 3 indicators and volume
+	grids := map[int]int{}
+	for _, s := range chart.MultiSeries {
+		if s.Name == "range-events" {
+			grids[s.XAxisIndex] = len(s.MarkAreas.Data)
+		}
+	}
+	assert.Equal(t, map[int]int{0: 2, 2: 1, 3: 1, 4: 1, 5: 1}, grids)
+	assert.Contains(t, string(chart.Tooltip.Formatter), `[[5,9,"earnings"],[15,20,""]]`)
+
+	cfg.AddRangeEvent(RangeEvent{StartLabel: "unknown", EndLabel: testCdls[1].Label})
+	_, err = New(*cfg).genChart(testCdls, testEvents)
+	assert.Equal(t, ErrUnknownRangeEventCandle, err)
+}
@@ -0,0 +1,238 @@
+package tachart
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+// Trade is a round-trip trade, drawn as a line from entry to exit on candlestick chart,
+// green if profitable and red otherwise. Entry and exit are matched to candles like events,
+// by Label or T. A trade without exit is an open position.
+//
+// Stop-loss and take-profit levels, if set, are drawn from the entry candle to the exit candle
+// (or the last candle of open positions), with the risk (entry to stop-loss) and the reward
+// (entry to take-profit) shaded.
+type Trade struct {
+	Side       EventType // Long or Short
+	Quantity   float64   // P&L is per unit if 0
+	EntryLabel string    // x-axis label of entry candle
+	EntryT     time.Time // entry time, optional. If set, entry is placed on the candle whose period contains EntryT
+	EntryPrice float64
+	ExitLabel  string    // x-axis label of exit candle, empty for open positions
+	ExitT      time.Time // exit time, optional. If set, exit is placed on the candle whose period contains ExitT
+	ExitPrice  float64
+	StopLoss   float64 // stop-loss level, optional
+	TakeProfit float64 // take-profit level, optional
+}
+
+// Open tells whether the trade is an open position, i.e. without exit.
+func (t Trade) Open() bool {
+	return t.ExitLabel == "" && t.ExitT.IsZero()
+}
+
+func (t Trade) entry() Event {
+	return Event{Label: t.EntryLabel, T: t.EntryT}
+}
+
+func (t Trade) exit() Event {
+	return Event{Label: t.ExitLabel, T: t.ExitT}
+}
+
+// validate checks the side and the prices of the trade, which returns are computed from.
+func (t Trade) validate() error {
+	if t.Side != Long && t.Side != Short {
+		return ErrInvalidTradeSide
+	}
+	if !(t.EntryPrice > 0) || (!t.Open() && !(t.ExitPrice > 0)) {
+		return ErrInvalidTradePrice
+	}
+	return nil
+}
+
+// PnL returns the profit (negative for loss) of the trade.
+func (t Trade) PnL() float64 {
+	qty := t.Quantity
+	if qty == 0 {
+		qty = 1
+	}
+	pnl := (t.ExitPrice - t.EntryPrice) * qty
+	if t.Side == Short {
+		pnl = -pnl
+	}
+	return pnl
+}
+
+// desc returns the tooltip description of the trade, held for # of candles (till the last candle
+// for open positions).
+func (t Trade) desc(candles int, dp int) string {
+	side := "Long"
+	if t.Side == Short {
+		side = "Short"
+	}
+	qty := ""
+	if t.Quantity != 0 {
+		qty = fmt.Sprintf(" %v", t.Quantity)
+	}
+	held := fmt.Sprintf("%v candles", candles)
+	if t.Open() {
+		return fmt.Sprintf("%v%v @ %.*f, open<br/>held %v", side, qty, dp, t.EntryPrice, held)
+	}
+	if !t.EntryT.IsZero() && !t.ExitT.IsZero() {
+		held += fmt.Sprintf(" (%v)", formatHoldingPeriod(t.ExitT.Sub(t.EntryT)))
+	}
+	ret := (t.ExitPrice - t.EntryPrice) / t.EntryPrice * 100
+	if t.Side == Short {
+		ret = -ret
+	}
+	return fmt.Sprintf("%v%v @ %.*f → %.*f<br/>P&L %+.*f (%+.2f%%), held %v",
+		side, qty, dp, t.EntryPrice, dp, t.ExitPrice, dp, t.PnL(), ret, held)
+}
+
+func formatHoldingPeriod(d time.Duration) string {
+	h := int(d.Hours())
+	if h >= 24 {
+		return fmt.Sprintf("%vd %vh", h/24, h%24)
+	}
+	return fmt.Sprintf("%vh %vm", h, int(d.Minutes())%60)
+}
+
+// genTradeChart draws trades as lines between entry and exit slots on candlestick chart, and entries
+// of open positions as dots, along with stop-loss and take-profit zones. Trade descriptions are added
+// to descMap at entry and exit slots.
+// Trades matching no candle are skipped if skipUnmatched, otherwise it's an error.
+func genTradeChart(trades []Trade, axis *candleAxis, dp int, descMap map[int]string, skipUnmatched bool) (charts.Overlaper, error) {
+	lines := []opts.MarkLineNameCoordItem{}
+	levels := []opts.MarkLineNameCoordItem{}
+	zones := []opts.MarkAreaNameCoordItem{}
+	// entries of open positions
+	entries := []opts.MarkPointNameCoordItem{}
+	for _, t := range trades {
+		if err := t.validate(); err != nil {
+			return nil, err
+		}
+		entry, err := axis.eventSlot(t.entry())
+		exit := entry
+		if err == nil {
+			if t.Open() {
+				// open positions last till the last candle, there is one as entry is matched
+				exit = axis.slots[len(axis.slots)-1]
+			} else {
+				exit, err = axis.eventSlot(t.exit())
+			}
+		}
+		if err != nil {
+			if skipUnmatched {
+				continue
+			}
+			return nil, ErrUnknownTradeCandle
+		}
+		if exit < entry {
+			return nil, ErrTradeExitBeforeEntry
+		}
+
+		for _, lvl := range []struct {
+			name  string
+			price float64
+			color string
+		}{
+			{"SL", t.StopLoss, colorDownBar},
+			{"TP", t.TakeProfit, colorUpBar},
+		} {
+			if lvl.price == 0 {
+				continue
+			}
+			levels = append(levels, opts.MarkLineNameCoordItem{
+				Coordinate0: []interface{}{entry, lvl.price},
+				Coordinate1: []interface{}{exit, lvl.price},
+				Label: &opts.Label{
+					Show:      opts.Bool(true),
+					Color:     lvl.color,
+					Formatter: fmt.Sprintf("%v %.*f", lvl.name, dp, lvl.price),
+				},
+				LineStyle: &opts.LineStyle{
+					Color: lvl.color,
+					Type:  "dashed",
+				},
+			})
+			zones = append(zones, opts.MarkAreaNameCoordItem{
+				Coordinate0: []interface{}{entry, t.EntryPrice},
+				Coordinate1: []interface{}{exit, lvl.price},
+				ItemStyle: &opts.ItemStyle{
+					Color:   lvl.color,
+					Opacity: opacityFill,
+				},
+			})
+		}
+
+		// candles between entry and exit
+		candles := sort.SearchInts(axis.slots, exit) - sort.SearchInts(axis.slots, entry)
+		desc := t.desc(candles, dp)
+		for _, slot := range []int{entry, exit} {
+			if descMap[slot] != "" {
+				descMap[slot] += "<br/>"
+			}
+			descMap[slot] += desc
+			if entry == exit || t.Open() {
+				break
+			}
+		}
+
+		if t.Open() {
+			color := colorUpBar
+			if t.Side == Short {
+				color = colorDownBar
+			}
+			entries = append(entries, opts.MarkPointNameCoordItem{
+				Coordinate: []interface{}{entry, t.EntryPrice},
+				Symbol:     "circle",
+				SymbolSize: 8,
+				ItemStyle: &opts.ItemStyle{
+					Color: color,
+				},
+				Label: &opts.Label{
+					Show:      opts.Bool(true),
+					Color:     color,
+					Position:  "right",
+					Formatter: "open",
+				},
+			})
+			continue
+		}
+		color := colorUpBar
+		if t.PnL() < 0 {
+			color = colorDownBar
+		}
+		lines = append(lines, opts.MarkLineNameCoordItem{
+			Coordinate0: []interface{}{entry, t.EntryPrice},
+			Coordinate1: []interface{}{exit, t.ExitPrice},
+			Label: &opts.Label{
+				Show:      opts.Bool(true),
+				Color:     color,
+				Formatter: fmt.Sprintf("%+.*f", dp, t.PnL()),
+			},
+			LineStyle: &opts.LineStyle{
+				Color: color,
+				Width: 2,
+			},
+		})
+	}
+
+	return charts.NewScatter().
+		AddSeries("trade-zones", []opts.ScatterData{},
+			charts.WithMarkAreaNameCoordItemOpts(zones...),
+			charts.WithMarkLineNameCoordItemOpts(levels...),
+			charts.WithMarkLineStyleOpts(opts.MarkLineStyle{
+				Symbol: []string{"none", "none"},
+			})).
+		AddSeries("trades", []opts.ScatterData{},
+			charts.WithMarkPointNameCoordItemOpts(entries...),
+			charts.WithMarkLineNameCoordItemOpts(lines...),
+			charts.WithMarkLineStyleOpts(opts.MarkLineStyle{
+				Symbol:     []string{"circle", "arrow"},
+				SymbolSize: 6,
+			})), nil
+}
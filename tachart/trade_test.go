@@ -0,0 +1,104 @@
+package tachart
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradePnL(t *testing.T) {
+	long := Trade{Side: Long, Quantity: 10, EntryPrice: 100, ExitPrice: 105}
+	assert.Equal(t, 50.0, long.PnL())
+	short := Trade{Side: Short, EntryPrice: 100, ExitPrice: 105}
+	assert.Equal(t, -5.0, short.PnL())
+
+	long.EntryT = time.Date(2021, 6, 4, 9, 0, 0, 0, time.UTC)
+	long.ExitT = time.Date(2021, 6, 7, 11, 30, 0, 0, time.UTC)
+	assert.Equal(t, "Long 10 @ 100.00 → 105.00<br/>P&L +50.00 (+5.00%), held 3 candles (3d 2h)", long.desc(3, 2))
+	assert.Equal(t, "2h 30m", formatHoldingPeriod(150*time.Minute))
+}
+
+func TestTradeChart(t *testing.T) {
+	trades := []Trade{
+		{Side: Long, EntryLabel: testCdls[2].Label, EntryPrice: 2300, ExitLabel: testCdls[6].Label, ExitPrice: 2410},
+		{Side: Short, EntryLabel: testCdls[10].Label, EntryPrice: 2420, ExitLabel: testCdls[12].Label, ExitPrice: 2430},
+	}
+	chart, err := New(*testConfig().AddTrade(trades...)).genChart(testCdls, testEvents)
+	assert.NoError(t, err)
+
+	found := false
+	for _, s := range chart.MultiSeries {
+		if s.Name == "trades" {
+			found = true
+			assert.Len(t, s.MarkLines.Data, 2)
+		}
+	}
+	assert.True(t, found)
+	formatter := string(chart.Tooltip.Formatter)
+	assert.True(t, strings.Contains(formatter, "P&L +110.00"))
+	// event and trade on the same candle
+	assert.True(t, strings.Contains(formatter, "go long on 2018/2/7<br/>Short @ 2420.00"))
+
+	for _, c := range []struct {
+		trade Trade
+		err   error
+	}{
+		{Trade{Side: Long, EntryLabel: testCdls[2].Label, EntryPrice: 2300, ExitLabel: "unknown", ExitPrice: 2410}, ErrUnknownTradeCandle},
+		{Trade{Side: Open, EntryLabel: testCdls[2].Label, EntryPrice: 2300}, ErrInvalidTradeSide},
+		{Trade{Side: Long, EntryLabel: testCdls[2].Label}, ErrInvalidTradePrice},
+		{Trade{Side: Long, EntryLabel: testCdls[2].Label, EntryPrice: 2300, ExitLabel: testCdls[6].Label}, ErrInvalidTradePrice},
+		{Trade{Side: Long, EntryLabel: testCdls[6].Label, EntryPrice: 2300, ExitLabel: testCdls[2].Label, ExitPrice: 2410}, ErrTradeExitBeforeEntry},
+	} {
+		_, err = New(*testConfig().AddTrade(c.trade)).genChart(testCdls, testEvents)
+		assert.Equal(t, c.err, err)
+	}
+}
+
+func TestTradeZones(t *testing.T) {
+	trades := []Trade{
+		{Side: Long, EntryLabel: testCdls[2].Label, EntryPrice: 2300, ExitLabel: testCdls[6].Label, ExitPrice: 2410,
+			StopLoss: 2280, TakeProfit: 2400},
+		// open position
+		{Side: Short, EntryLabel: testCdls[20].Label, EntryPrice: 2360, StopLoss: 2400},
+	}
+	assert.False(t, trades[0].Open())
+	assert.True(t, trades[1].Open())
+
+	chart, err := New(*testConfig().AddTrade(trades...)).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	found := 0
+	for _, s := range chart.MultiSeries {
+		switch s.Name {
+		case "trades":
+			found++
+			assert.Len(t, s.MarkLines.Data, 1)
+			// entry of open position
+			assert.Len(t, s.MarkPoints.Data, 1)
+			assert.Contains(t, toJson(s.MarkPoints.Data[0]), `"coord":[20,2360]`)
+		case "trade-zones":
+			found++
+			assert.Len(t, s.MarkLines.Data, 3)
+			assert.Len(t, s.MarkAreas.Data, 3)
+			// open position lasts till the last candle
+			assert.Contains(t, toJson(s.MarkAreas.Data[2]), `"coord":[29,2400]`)
+		}
+	}
+	assert.Equal(t, 2, found)
+	assert.Contains(t, string(chart.Tooltip.Formatter), "Short @ 2360.00, open<br/>held 9 candles")
+
+	// open position without stop-loss and take-profit
+	chart, err = New(*testConfig().AddTrade(Trade{Side: Long, EntryLabel: testCdls[25].Label, EntryPrice: 2200})).genChart(testCdls, nil)
+	assert.NoError(t, err)
+	for _, s := range chart.MultiSeries {
+		if s.Name == "trades" {
+			assert.Len(t, s.MarkPoints.Data, 1)
+		}
+	}
+	assert.Contains(t, string(chart.Tooltip.Formatter), "Long @ 2200.00, open")
+
+	// no candles
+	_, err = New(*testConfig().AddTrade(trades...)).genChart(nil, nil)
+	assert.Equal(t, ErrUnknownTradeCandle, err)
+}
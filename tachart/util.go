@@ -2,7 +2,11 @@ package tachart
 
 import (
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
 )
 
 func countDecimalPlaces(v float64) int {
@@ -26,9 +30,12 @@ func maxDecimalPlaces(cdls []Candle) int {
 }
 
 func span(arr []float64) float64 {
-	min := arr[0]
-	max := arr[0]
+	min := math.Inf(1)
+	max := math.Inf(-1)
 	for _, v := range arr {
+		if math.IsNaN(v) {
+			continue
+		}
 		if v < min {
 			min = v
 		}
@@ -36,6 +43,9 @@ func span(arr []float64) float64 {
 			max = v
 		}
 	}
+	if min > max {
+		return 0
+	}
 	return max - min
 }
 
@@ -72,3 +82,44 @@ func decimals(arr ...[]float64) int {
 	}
 	return dp
 }
+
+// lineData converts vals into line series data, NaN values are left blank.
+func lineData(vals []float64) []opts.LineData {
+	items := []opts.LineData{}
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			items = append(items, opts.LineData{Value: "-"})
+		} else {
+			items = append(items, opts.LineData{Value: v})
+		}
+	}
+	return items
+}
+
+// gapWarmUp blanks the first n values of arr, which are not available during the warm-up period of indicators.
+func gapWarmUp(n int, arr ...[]float64) {
+	for _, vals := range arr {
+		for i := 0; i < n && i < len(vals); i++ {
+			vals[i] = math.NaN()
+		}
+	}
+}
+
+// backfillWarmUp fills blank values of line series before the first available value with it.
+// Series excluded by ind are left as is.
+func backfillWarmUp(ind Indicator, series charts.MultiSeries) {
+	excluder, _ := ind.(backfillExcluder)
+	for _, s := range series {
+		data, ok := s.Data.([]opts.LineData)
+		if !ok || (excluder != nil && excluder.noBackfill(s.Name)) {
+			continue
+		}
+		first := 0
+		for first < len(data) && data[first].Value == "-" {
+			first++
+		}
+		for i := 0; i < first && first < len(data); i++ {
+			data[i].Value = data[first].Value
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package tachart
+
+import (
+	"github.com/iamjinlei/go-tart"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+// volumeFlow is a cumulative volume line, e.g. OBV and A/D.
+type volumeFlow struct {
+	nm string
+	fn func(highs, lows, closes, vols []float64) []float64
+	ci int
+}
+
+// NewOBV creates on-balance volume indicator.
+func NewOBV() Indicator {
+	return &volumeFlow{
+		nm: "OBV",
+		fn: func(_, _, closes, vols []float64) []float64 {
+			return tart.ObvArr(closes, vols)
+		},
+	}
+}
+
+// NewAD creates accumulation/distribution line indicator.
+func NewAD() Indicator {
+	return &volumeFlow{
+		nm: "A/D",
+		fn: tart.AdArr,
+	}
+}
+
+func (c volumeFlow) Name() string {
+	return c.nm
+}
+
+func (c volumeFlow) YAxisLabel() string {
+	return CompactLabelFormatterFunc()
+}
+
+func (c volumeFlow) YAxisMin() string {
+	return MinPadFunc()
+}
+
+func (c volumeFlow) YAxisMax() string {
+	return MaxPadFunc()
+}
+
+func (c volumeFlow) GetNumColors() int {
+	return 1
+}
+
+func (c *volumeFlow) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	c.ci = colorIndex
+	return []opts.Title{
+		LegendTitle(c.nm, top, left, c.ci),
+	}
+}
+
+func (c volumeFlow) GenChart(_, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	return charts.NewLine().
+		SetXAxis(xAxis).
+		AddSeries(c.nm, lineData(c.fn(highs, lows, closes, vols)),
+			charts.WithLineChartOpts(opts.LineChart{
+				Symbol:     "none",
+				XAxisIndex: gridIndex,
+				YAxisIndex: gridIndex,
+			}),
+			charts.WithLineStyleOpts(opts.LineStyle{
+				Color:   Color(c.ci),
+				Opacity: opacityMed,
+			}),
+		)
+}
@@ -0,0 +1,32 @@
+package tachart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeFlow(t *testing.T) {
+	cfg := NewConfig().AddIndicator(NewOBV(), NewAD(), NewCMF(3), NewMFI(3, 20, 80))
+	chart, err := New(*cfg).genChart(testCdls, nil)
+	assert.NoError(t, err)
+
+	obv := seriesValues(t, chart.MultiSeries, "OBV")
+	// 2nd candle closes lower
+	assert.Equal(t, testCdls[0].V-testCdls[1].V, obv[1])
+
+	cmf := seriesValues(t, chart.MultiSeries, "CMF(3)")
+	assert.Equal(t, "-", cmf[1])
+	mfv := 0.0
+	v := 0.0
+	for _, cdl := range testCdls[:3] {
+		mfv += ((cdl.C - cdl.L) - (cdl.H - cdl.C)) / (cdl.H - cdl.L) * cdl.V
+		v += cdl.V
+	}
+	assert.InDelta(t, mfv/v, cmf[2], 1e-9)
+
+	mfi := seriesValues(t, chart.MultiSeries, "MFI(3)")
+	assert.Equal(t, "-", mfi[2])
+	assert.IsType(t, 0.0, mfi[3])
+	assert.Len(t, seriesValues(t, chart.MultiSeries, "A/D"), len(testCdls))
+}
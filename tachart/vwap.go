@@ -0,0 +1,226 @@
+package tachart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+// SessionBoundary tells if candle cur starts a new session after candle prev.
+type SessionBoundary func(prev, cur Candle) bool
+
+// DailySession starts a new session on every calendar day in loc, UTC if nil. Candles without time
+// (Candle.T) never start a new session.
+func DailySession(loc *time.Location) SessionBoundary {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(prev, cur Candle) bool {
+		if prev.T.IsZero() || cur.T.IsZero() {
+			return false
+		}
+		py, pm, pd := prev.T.In(loc).Date()
+		cy, cm, cd := cur.T.In(loc).Date()
+		return py != cy || pm != cm || pd != cd
+	}
+}
+
+// WeeklySession starts a new session on every ISO week in loc, UTC if nil.
+func WeeklySession(loc *time.Location) SessionBoundary {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(prev, cur Candle) bool {
+		if prev.T.IsZero() || cur.T.IsZero() {
+			return false
+		}
+		py, pw := prev.T.In(loc).ISOWeek()
+		cy, cw := cur.T.In(loc).ISOWeek()
+		return py != cy || pw != cw
+	}
+}
+
+// MonthlySession starts a new session on every calendar month in loc, UTC if nil.
+func MonthlySession(loc *time.Location) SessionBoundary {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(prev, cur Candle) bool {
+		if prev.T.IsZero() || cur.T.IsZero() {
+			return false
+		}
+		py, pm, _ := prev.T.In(loc).Date()
+		cy, cm, _ := cur.T.In(loc).Date()
+		return py != cy || pm != cm
+	}
+}
+
+type vwap struct {
+	nm       string
+	boundary SessionBoundary
+	anchor   string
+	anchored bool
+	opts     indicatorOptions
+	ci       int
+}
+
+// NewVWAP creates volume weighted average price overlay, which resets at every session boundary.
+// nil sessionBoundary means a single session for all candles.
+func NewVWAP(sessionBoundary SessionBoundary, options ...IndicatorOption) Indicator {
+	return &vwap{
+		nm:       "VWAP",
+		boundary: sessionBoundary,
+		opts:     newIndicatorOptions(options),
+	}
+}
+
+// NewAnchoredVWAP creates volume weighted average price overlay starting from the candle
+// labeled anchorLabel, e.g. the label of an event. The anchor is matched to candles like events,
+// it's an error if no candle matches.
+func NewAnchoredVWAP(anchorLabel string, options ...IndicatorOption) Indicator {
+	return &vwap{
+		nm:       fmt.Sprintf("AVWAP(%v)", anchorLabel),
+		anchor:   anchorLabel,
+		anchored: true,
+		opts:     newIndicatorOptions(options),
+	}
+}
+
+func (c vwap) anchorEvent() (Event, bool) {
+	return Event{Label: c.anchor}, c.anchored
+}
+
+// noBackfill excludes anchored VWAP, which is blank before the anchor.
+func (c vwap) noBackfill(string) bool {
+	return c.anchored
+}
+
+func (c vwap) Name() string {
+	return c.nm
+}
+
+func (c vwap) YAxisLabel() string {
+	return ""
+}
+
+func (c vwap) YAxisMin() string {
+	return ""
+}
+
+func (c vwap) YAxisMax() string {
+	return ""
+}
+
+func (c vwap) GetNumColors() int {
+	if len(c.opts.stdDevs) > 0 {
+		return 2
+	}
+	return 1
+}
+
+func (c *vwap) GetTitleOpts(top, left int, colorIndex int) []opts.Title {
+	c.ci = colorIndex
+	titles := []opts.Title{
+		LegendTitle(c.nm, top, left, c.ci),
+	}
+	if len(c.opts.stdDevs) > 0 {
+		ks := []string{}
+		for _, k := range c.opts.stdDevs {
+			ks = append(ks, fmt.Sprintf("%v", k))
+		}
+		titles = append(titles, LegendTitle(fmt.Sprintf("%v-Bands(%v)", c.nm, strings.Join(ks, ",")), top+chartLabelFontHeight, left, c.ci+1))
+	}
+	return titles
+}
+
+func (c vwap) GenChart(opens, highs, lows, closes, vols []float64, xAxis interface{}, gridIndex int) charts.Overlaper {
+	cdls := []Candle{}
+	for i := range closes {
+		cdls = append(cdls, Candle{O: opens[i], H: highs[i], L: lows[i], C: closes[i], V: vols[i]})
+	}
+	return c.GenCandleChart(cdls, xAxis, gridIndex)
+}
+
+func (c vwap) GenCandleChart(cdls []Candle, xAxis interface{}, gridIndex int) charts.Overlaper {
+	vals := make([]float64, len(cdls))
+	devs := make([]float64, len(cdls))
+	start := 0
+	if c.anchored {
+		// nothing is drawn if the anchor matches no candle
+		start = len(cdls)
+		if axis, err := newCandleAxis(Config{}, cdls); err == nil {
+			if slot, err := axis.eventSlot(Event{Label: c.anchor}); err == nil {
+				start = slot
+			}
+		}
+	}
+	var sumV, sumPV, sumPPV float64
+	for i, cdl := range cdls {
+		if !c.anchored && i > 0 && c.boundary != nil && c.boundary(cdls[i-1], cdl) {
+			sumV, sumPV, sumPPV = 0, 0, 0
+		}
+		if i < start {
+			vals[i], devs[i] = math.NaN(), math.NaN()
+			continue
+		}
+
+		// typical price
+		p := (cdl.H + cdl.L + cdl.C) / 3
+		sumV += cdl.V
+		sumPV += p * cdl.V
+		sumPPV += p * p * cdl.V
+		if sumV == 0 {
+			vals[i], devs[i] = p, 0
+			continue
+		}
+		vals[i] = sumPV / sumV
+		devs[i] = math.Sqrt(math.Max(sumPPV/sumV-vals[i]*vals[i], 0))
+	}
+
+	line := func(nm string, vals []float64, ci int) *charts.Line {
+		return charts.NewLine().
+			SetXAxis(xAxis).
+			AddSeries(nm, lineData(vals),
+				charts.WithLineChartOpts(opts.LineChart{
+					Symbol:     "none",
+					XAxisIndex: gridIndex,
+					YAxisIndex: gridIndex,
+				}),
+				charts.WithLineStyleOpts(opts.LineStyle{
+					Color:   Color(ci),
+					Opacity: opacityMed,
+				}))
+	}
+
+	l := line(c.nm, vals, c.ci)
+	// band fill covers the widest band
+	var fillUpper, fillLower []float64
+	maxK := 0.0
+	for _, k := range c.opts.stdDevs {
+		upper := make([]float64, len(vals))
+		lower := make([]float64, len(vals))
+		for i := range vals {
+			upper[i] = vals[i] + k*devs[i]
+			lower[i] = vals[i] - k*devs[i]
+		}
+		l.Overlap(
+			line(fmt.Sprintf("%v-Upper(%v)", c.nm, k), upper, c.ci+1),
+			line(fmt.Sprintf("%v-Lower(%v)", c.nm, k), lower, c.ci+1),
+		)
+		if k > maxK {
+			fillUpper, fillLower, maxK = upper, lower, k
+		}
+	}
+	if c.opts.fill && fillUpper != nil {
+		color := c.opts.fillColor
+		if color == "" {
+			color = Color(c.ci + 1)
+		}
+		l.Overlap(BandFill(c.nm, fillUpper, fillLower, color, xAxis, gridIndex))
+	}
+	return l
+}
@@ -0,0 +1,68 @@
+package tachart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/otetz/go-tachart/charts"
+	"github.com/otetz/go-tachart/opts"
+)
+
+func seriesValues(t *testing.T, series charts.MultiSeries, name string) []interface{} {
+	for _, s := range series {
+		if s.Name == name {
+			vals := []interface{}{}
+			for _, d := range s.Data.([]opts.LineData) {
+				vals = append(vals, d.Value)
+			}
+			return vals
+		}
+	}
+	t.Fatalf("series %v not found", name)
+	return nil
+}
+
+func TestVWAP(t *testing.T) {
+	cdls := hourlyCandles("2021-06-03 22:00", "2021-06-03 23:00", "2021-06-04 00:00", "2021-06-04 01:00")
+	cdls[1].H, cdls[1].L, cdls[1].C, cdls[1].V = 4, 1, 4, 300
+	cdls[3].H, cdls[3].L, cdls[3].C, cdls[3].V = 5, 2, 5, 100
+
+	chart := NewVWAP(DailySession(time.UTC), WithStdDevBands(1, 2)).(CandleIndicator).GenCandleChart(cdls, nil, 0).(*charts.Line)
+	vals := seriesValues(t, chart.MultiSeries, "VWAP")
+	assert.InDeltaSlice(t, []float64{4.0 / 3, 2.5833, 4.0 / 3, 2.6667}, toFloats(vals), 0.0001)
+	// nil location is UTC
+	assert.True(t, DailySession(nil)(cdls[1], cdls[2]))
+
+	titles := NewVWAP(nil, WithStdDevBands(1, 2.5)).GetTitleOpts(0, 0, 0)
+	assert.Equal(t, "VWAP-Bands(1,2.5)", titles[1].Title)
+	upper := seriesValues(t, chart.MultiSeries, "VWAP-Upper(2)")
+	assert.InDelta(t, 4.0/3, upper[0], 0.0001)
+	assert.Greater(t, upper[1], vals[1])
+
+	cdls[1].Label = "anchor"
+	chart = NewAnchoredVWAP("anchor").(CandleIndicator).GenCandleChart(cdls, nil, 0).(*charts.Line)
+	vals = seriesValues(t, chart.MultiSeries, "AVWAP(anchor)")
+	assert.Equal(t, "-", vals[0])
+	assert.InDeltaSlice(t, []float64{3, 2.5833, 2.8667}, toFloats(vals[1:]), 0.0001)
+
+	// candles keyed by time are anchored by formatted labels
+	cdls[1].Label = ""
+	kline, err := New(*NewConfig().AddOverlay(NewAnchoredVWAP("2021/06/03 23:00"))).genChart(cdls, nil)
+	assert.NoError(t, err)
+	vals = seriesValues(t, kline.MultiSeries, "AVWAP(2021/06/03 23:00)")
+	assert.Equal(t, "-", vals[0])
+	assert.InDelta(t, 3, vals[1], 0.0001)
+
+	_, err = New(*NewConfig().AddOverlay(NewAnchoredVWAP("unknown"))).genChart(cdls, nil)
+	assert.Equal(t, ErrUnknownAnchorCandle, err)
+}
+
+func toFloats(vals []interface{}) []float64 {
+	ret := []float64{}
+	for _, v := range vals {
+		ret = append(ret, v.(float64))
+	}
+	return ret
+}